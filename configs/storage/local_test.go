@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "receipts/2026/scan.pdf", want: true},
+		{key: "scan.pdf", want: true},
+		{key: "a/.hidden", want: true},
+		{key: ""},
+		{key: "/etc/passwd"},
+		{key: "../secret"},
+		{key: "receipts/../../secret"},
+		{key: "receipts/./scan.pdf"},
+		{key: "receipts//scan.pdf"},
+		{key: "receipts/"},
+		{key: `receipts\..\secret`},
+	}
+
+	for _, tt := range tests {
+		if got := validKey(tt.key); got != tt.want {
+			t.Errorf("validKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "objects")
+
+	s, err := NewLocalStorage(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "receipts/2026/scan.pdf"
+
+	for _, content := range []string{"first", "second"} {
+		if err = s.Put(ctx, key, "application/pdf", strings.NewReader(content)); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+
+		r, err := s.Get(ctx, key)
+
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+
+		if err != nil || string(got) != content {
+			t.Fatalf("Get() = %q, %v, want %q", got, err, content)
+		}
+	}
+
+	// The temporary upload files are renamed or removed, never left next to the object.
+	files, err := ioutil.ReadDir(filepath.Join(root, "receipts", "2026"))
+
+	if err != nil || len(files) != 1 {
+		t.Errorf("object directory holds %d files, %v", len(files), err)
+	}
+
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = s.Get(ctx, key); err != ErrNotExist {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotExist)
+	}
+
+	if err = s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+}
+
+func TestLocalStorageRejectsTraversal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "objects")
+
+	s, err := NewLocalStorage(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "secret"), []byte("keep"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret", "receipts/../../secret", "/secret"} {
+		if err = s.Put(ctx, key, "text/plain", strings.NewReader("overwritten")); err != ErrInvalidKey {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+
+		if _, err = s.Get(ctx, key); err != ErrInvalidKey {
+			t.Errorf("Get(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+
+		if err = s.Delete(ctx, key); err != ErrInvalidKey {
+			t.Errorf("Delete(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+	}
+
+	if got, err := ioutil.ReadFile(filepath.Join(dir, "secret")); err != nil || string(got) != "keep" {
+		t.Errorf("file outside the root = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 keeps objects in memory and rejects any request whose Signature Version 4 headers do not match what it
+// received, recomputing the signature from the method, path, host and body as S3 does.
+type fakeS3 struct {
+	t       *testing.T
+	config  S3Config
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeS3) verify(r *http.Request, body []byte) error {
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+
+	if payloadHash != sha256Hex(body) {
+		return fmt.Errorf("x-amz-content-sha256 %q does not match the body", payloadHash)
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	now, err := time.Parse("20060102T150405Z", amzDate)
+
+	if err != nil {
+		return fmt.Errorf("x-amz-date %q: %v", amzDate, err)
+	}
+
+	day := now.Format("20060102")
+	scope := day + "/" + f.config.Region + "/s3/aws4_request"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + r.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{r.Method, r.URL.EscapedPath(), r.URL.RawQuery, canonicalHeaders, signedHeaders, payloadHash}, "\n")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+f.config.SecretKey), day)
+	key = hmacSHA256(key, f.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	want := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		f.config.AccessKey, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign)))
+
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("authorization %q, want %q", got, want)
+	}
+
+	return nil
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = f.verify(r, body); err != nil {
+		f.t.Errorf("%s %s: %v", r.Method, r.URL.EscapedPath(), err)
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.EscapedPath()
+	_, found := f.objects[path]
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[path] = string(body)
+		f.types[path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		if !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(f.objects[path]))
+	case http.MethodDelete:
+		// S3 itself answers 204 for a missing key; MinIO and others may answer 404, which Delete also accepts.
+		if !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+
+		delete(f.objects, path)
+		delete(f.types, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	ctx := context.Background()
+	config := S3Config{Region: "ap-southeast-1", Bucket: "fin-api", AccessKey: "AKIDEXAMPLE", SecretKey: "secret"}
+	fake := &fakeS3{t: t, config: config, objects: make(map[string]string), types: make(map[string]string)}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config.Endpoint = server.URL + "/"
+	s := NewS3Storage(config)
+
+	key := "receipts/2026/scan 1+2.pdf"
+	path := "/fin-api/receipts/2026/scan%201%2B2.pdf"
+
+	if err := s.Put(ctx, key, "application/pdf", strings.NewReader("receipt")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if fake.objects[path] != "receipt" || fake.types[path] != "application/pdf" {
+		t.Errorf("stored %q as %q, want %q as %q", fake.objects[path], fake.types[path], "receipt", "application/pdf")
+	}
+
+	r, err := s.Get(ctx, key)
+
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+
+	if err != nil || string(got) != "receipt" {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "receipt")
+	}
+
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = s.Get(ctx, key); err != ErrNotExist {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotExist)
+	}
+
+	if err = s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+
+	if err = s.Put(ctx, "../escape", "text/plain", strings.NewReader("x")); err != ErrInvalidKey {
+		t.Errorf("Put() of an invalid key error = %v, want %v", err, ErrInvalidKey)
+	}
+}
+
+func TestS3StorageRejectedSignature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "fin-api", AccessKey: "AKIDEXAMPLE", SecretKey: "wrong"})
+
+	err := s.Put(context.Background(), "scan.pdf", "application/pdf", strings.NewReader("receipt"))
+
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Put() error = %v, want the service's message", err)
+	}
+}
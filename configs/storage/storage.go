@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotExist is returned when no object is stored under the requested key.
+var ErrNotExist = errors.New("object does not exist")
+
+// ErrInvalidKey is returned for keys that are empty, absolute or climb out of the storage root.
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Storage keeps binary objects, such as receipt scans, under slash separated keys.
+type Storage interface {
+	Put(ctx context.Context, key string, contentType string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+
+	return true
+}
@@ -18,6 +18,26 @@ var (
 	ErrBadParamInput = errors.New("Given Param is not valid")
 	// ErrWrongPassword will throw if the given password is not valid
 	ErrWrongPassword = errors.New("Given Password is not valid")
+	// ErrRateNotFound will throw if no exchange rate converts between the requested currencies
+	ErrRateNotFound = errors.New("Exchange rate is not found")
+	// ErrLinkedTransfer will throw if a transfer leg is changed on its own instead of through its transfer
+	ErrLinkedTransfer = errors.New("Transaction is part of a transfer")
+	// ErrLinkedTrade will throw if the cash transaction of a trade is changed on its own instead of through its trade
+	ErrLinkedTrade = errors.New("Transaction is part of a trade")
+	// ErrSplitMismatch will throw if the splits of a transaction do not add up to its amount
+	ErrSplitMismatch = errors.New("Splits do not add up to the transaction amount")
+	// ErrFileTooLarge will throw if an uploaded file is bigger than allowed
+	ErrFileTooLarge = errors.New("File is too large")
+	// ErrUnsupportedMediaType will throw if an uploaded file is not of an accepted type
+	ErrUnsupportedMediaType = errors.New("File type is not supported")
+	// ErrReconciled will throw if a reconciled transaction is changed or deleted
+	ErrReconciled = errors.New("Transaction is reconciled")
+	// ErrNotBalanced will throw if a reconciliation is finalized while its cleared balance differs from the statement
+	ErrNotBalanced = errors.New("Cleared balance does not match the statement balance")
+	// ErrPeriodClosed will throw if a transaction dated inside a closed period is created, changed or deleted
+	ErrPeriodClosed = errors.New("Transaction is in a closed period")
+	// ErrVoided will throw if a voided transaction or its reversal is voided again, changed or deleted
+	ErrVoided = errors.New("Transaction is voided")
 )
 
 func GetStatusCode(err error) int {
@@ -16,6 +16,20 @@ func VerifyEmail(email string) error {
 	return nil
 }
 
+// DefaultCurrency is used for accounts created without a currency code.
+const DefaultCurrency = "IDR"
+
+// VerifyCurrency checks that code looks like an ISO 4217 currency code such as IDR or USD.
+func VerifyCurrency(code string) error {
+	re := regexp.MustCompile("^[A-Z]{3}$")
+
+	if re.MatchString(code) == false {
+		return ErrBadParamInput
+	}
+
+	return nil
+}
+
 func EncryptPassword(password string) string {
 	sum := sha256.Sum256([]byte(password))
 	return fmt.Sprintf("%x", sum)
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
 	"github.com/arham09/fin-api/configs/database"
+	"github.com/arham09/fin-api/configs/storage"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 
@@ -15,17 +17,89 @@ import (
 
 	mid "github.com/arham09/fin-api/middleware"
 
+	ch "github.com/arham09/fin-api/modules/category/delivery/http"
+	cr "github.com/arham09/fin-api/modules/category/repository"
+	cu "github.com/arham09/fin-api/modules/category/usecase"
+
+	bgh "github.com/arham09/fin-api/modules/budget/delivery/http"
+	bgr "github.com/arham09/fin-api/modules/budget/repository"
+	bgu "github.com/arham09/fin-api/modules/budget/usecase"
+
 	uh "github.com/arham09/fin-api/modules/user/delivery/http"
 	ur "github.com/arham09/fin-api/modules/user/repository"
 	uu "github.com/arham09/fin-api/modules/user/usecase"
 
+	adh "github.com/arham09/fin-api/modules/audit/delivery/http"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	adu "github.com/arham09/fin-api/modules/audit/usecase"
+
 	ah "github.com/arham09/fin-api/modules/account/delivery/http"
 	ar "github.com/arham09/fin-api/modules/account/repository"
 	au "github.com/arham09/fin-api/modules/account/usecase"
 
+	bh "github.com/arham09/fin-api/modules/balance/delivery/http"
+	br "github.com/arham09/fin-api/modules/balance/repository"
+	bu "github.com/arham09/fin-api/modules/balance/usecase"
+
+	eh "github.com/arham09/fin-api/modules/exchangerate/delivery/http"
+	er "github.com/arham09/fin-api/modules/exchangerate/repository"
+	eu "github.com/arham09/fin-api/modules/exchangerate/usecase"
+
+	sth "github.com/arham09/fin-api/modules/attachment/delivery/http"
+	str "github.com/arham09/fin-api/modules/attachment/repository"
+	stu "github.com/arham09/fin-api/modules/attachment/usecase"
+
+	tfh "github.com/arham09/fin-api/modules/transfer/delivery/http"
+	tfr "github.com/arham09/fin-api/modules/transfer/repository"
+	tfu "github.com/arham09/fin-api/modules/transfer/usecase"
+
 	th "github.com/arham09/fin-api/modules/transaction/delivery/http"
 	tr "github.com/arham09/fin-api/modules/transaction/repository"
 	tu "github.com/arham09/fin-api/modules/transaction/usecase"
+
+	ih "github.com/arham09/fin-api/modules/importer/delivery/http"
+	ir "github.com/arham09/fin-api/modules/importer/repository"
+	iu "github.com/arham09/fin-api/modules/importer/usecase"
+
+	smh "github.com/arham09/fin-api/modules/statement/delivery/http"
+	smu "github.com/arham09/fin-api/modules/statement/usecase"
+
+	rnh "github.com/arham09/fin-api/modules/reconciliation/delivery/http"
+	rnr "github.com/arham09/fin-api/modules/reconciliation/repository"
+	rnu "github.com/arham09/fin-api/modules/reconciliation/usecase"
+
+	tsh "github.com/arham09/fin-api/modules/trash/delivery/http"
+	tss "github.com/arham09/fin-api/modules/trash/delivery/scheduler"
+	tsu "github.com/arham09/fin-api/modules/trash/usecase"
+
+	pdh "github.com/arham09/fin-api/modules/period/delivery/http"
+	pdr "github.com/arham09/fin-api/modules/period/repository"
+	pdu "github.com/arham09/fin-api/modules/period/usecase"
+
+	lnh "github.com/arham09/fin-api/modules/loan/delivery/http"
+	lnr "github.com/arham09/fin-api/modules/loan/repository"
+	lnu "github.com/arham09/fin-api/modules/loan/usecase"
+
+	sch "github.com/arham09/fin-api/modules/security/delivery/http"
+	scr "github.com/arham09/fin-api/modules/security/repository"
+	scu "github.com/arham09/fin-api/modules/security/usecase"
+
+	ivh "github.com/arham09/fin-api/modules/investment/delivery/http"
+	ivr "github.com/arham09/fin-api/modules/investment/repository"
+	ivu "github.com/arham09/fin-api/modules/investment/usecase"
+
+	glh "github.com/arham09/fin-api/modules/goal/delivery/http"
+	glr "github.com/arham09/fin-api/modules/goal/repository"
+	glu "github.com/arham09/fin-api/modules/goal/usecase"
+
+	rch "github.com/arham09/fin-api/modules/recurring/delivery/http"
+	rcs "github.com/arham09/fin-api/modules/recurring/delivery/scheduler"
+	rcr "github.com/arham09/fin-api/modules/recurring/repository"
+	rcu "github.com/arham09/fin-api/modules/recurring/usecase"
+
+	rph "github.com/arham09/fin-api/modules/report/delivery/http"
+	rpr "github.com/arham09/fin-api/modules/report/repository"
+	rpu "github.com/arham09/fin-api/modules/report/usecase"
 )
 
 func init() {
@@ -81,23 +155,156 @@ func main() {
 	// e.Use(middleware.Gzip())
 
 	// Init middleware for handler
-	middl := mid.InitMiddleware()
+	userRepo := ur.NewMysqlUserRepository(db)
+	middl := mid.InitMiddleware(userRepo)
+	e.Use(middl.RequestID)
 	timeoutContext := time.Duration(5) * time.Second
 
+	//Category Modules
+	categoryRepo := cr.NewMysqlCategoryRepository(db)
+	categoryUsecase := cu.NewCategoryUsecase(categoryRepo, timeoutContext)
+	ch.NewCategoryHandler(e, categoryUsecase, middl)
+
+	//Budget Modules
+	budgetRepo := bgr.NewMysqlBudgetRepository(db)
+	budgetUsecase := bgu.NewBudgetUsecase(budgetRepo, categoryRepo, timeoutContext)
+	bgh.NewBudgetHandler(e, budgetUsecase, middl)
+
 	//User Modules
-	userRepo := ur.NewMysqlUserRepository(db)
 	userUsecase := uu.NewUserUsecase(userRepo, timeoutContext)
 	uh.NewUserHandler(e, userUsecase, middl)
 
+	//Audit Modules
+	auditRepo := adr.NewMysqlAuditRepository(db)
+	auditUsecase := adu.NewAuditUsecase(auditRepo, timeoutContext)
+	adh.NewAuditHandler(e, auditUsecase, middl)
+
+	//Period Close Modules
+	periodRepo := pdr.NewMysqlPeriodRepository(db)
+	periodUsecase := pdu.NewPeriodUsecase(periodRepo, timeoutContext)
+	pdh.NewPeriodHandler(e, periodUsecase, middl)
+
 	//Account Modules
 	accountRepo := ar.NewMysqlAccountRepository(db)
 	accountUsecase := au.NewAccountUsecase(accountRepo, timeoutContext)
 	ah.NewAccountHandler(e, accountUsecase, middl)
 
-	//Trx Modules
+	//Balance Modules
+	balanceRepo := br.NewMysqlBalanceRepository(db)
+	balanceUsecase := bu.NewBalanceUsecase(balanceRepo, timeoutContext)
+	bh.NewBalanceHandler(e, balanceUsecase, middl)
+
+	//Exchange Rate Modules
+	rateRepo := er.NewMysqlExchangeRateRepository(db)
+	rateUsecase := eu.NewExchangeRateUsecase(rateRepo, timeoutContext)
+	eh.NewExchangeRateHandler(e, rateUsecase, middl)
+
+	//Loan Modules
+	loanRepo := lnr.NewMysqlLoanRepository(db)
+	loanUsecase := lnu.NewLoanUsecase(loanRepo, accountRepo, timeoutContext)
+	lnh.NewLoanHandler(e, loanUsecase, middl)
+
+	//Attachment Modules
+	fileStorage, err := newStorage()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	trxRepo := tr.NewMysqlTrxRepository(db)
-	trxUsecase := tu.NewTrxRepo(trxRepo, accountRepo, timeoutContext)
+	attachmentRepo := str.NewMysqlAttachmentRepository(db)
+	attachmentUsecase := stu.NewAttachmentUsecase(attachmentRepo, trxRepo, fileStorage, timeoutContext)
+	sth.NewAttachmentHandler(e, attachmentUsecase, middl)
+
+	//Transfer Modules
+	transferRepo := tfr.NewMysqlTransferRepository(db)
+	transferUsecase := tfu.NewTransferUsecase(transferRepo, accountRepo, trxRepo, loanRepo, timeoutContext)
+	tfh.NewTransferHandler(e, transferUsecase, middl)
+
+	//Trx Modules
+	trxUsecase := tu.NewTrxRepo(trxRepo, accountRepo, transferRepo, rateRepo, categoryRepo, timeoutContext)
 	th.NewAccountHandler(e, trxUsecase, middl)
 
+	//Import Modules
+	importRepo := ir.NewMysqlImportRepository(db)
+	importUsecase := iu.NewImportUsecase(importRepo, accountRepo, trxRepo, balanceRepo, categoryRepo, timeoutContext)
+	ih.NewImportHandler(e, importUsecase, middl)
+
+	//Statement Modules
+	statementUsecase := smu.NewStatementUsecase(accountRepo, balanceRepo, trxRepo, timeoutContext)
+	smh.NewStatementHandler(e, statementUsecase, middl)
+
+	//Reconciliation Modules
+	reconciliationRepo := rnr.NewMysqlReconciliationRepository(db)
+	reconciliationUsecase := rnu.NewReconciliationUsecase(reconciliationRepo, accountRepo, trxRepo, timeoutContext)
+	rnh.NewReconciliationHandler(e, reconciliationUsecase, middl)
+
+	//Security Modules
+	securityRepo := scr.NewMysqlSecurityRepository(db)
+	securityUsecase := scu.NewSecurityUsecase(securityRepo, timeoutContext)
+	sch.NewSecurityHandler(e, securityUsecase, middl)
+
+	//Investment Modules
+	investmentRepo := ivr.NewMysqlInvestmentRepository(db)
+	investmentUsecase := ivu.NewInvestmentUsecase(investmentRepo, accountRepo, securityRepo, timeoutContext)
+	ivh.NewInvestmentHandler(e, investmentUsecase, middl)
+
+	//Goal Modules
+	goalRepo := glr.NewMysqlGoalRepository(db)
+	goalUsecase := glu.NewGoalUsecase(goalRepo, accountRepo, rateRepo, timeoutContext)
+	glh.NewGoalHandler(e, goalUsecase, middl)
+
+	//Trash Modules
+	trashUsecase := tsu.NewTrashUsecase(accountRepo, trxRepo, attachmentUsecase, timeoutContext)
+	tsh.NewTrashHandler(e, trashUsecase, middl)
+
+	//Recurring Modules
+	recurringRepo := rcr.NewMysqlRecurringRepository(db)
+	recurringUsecase := rcu.NewRecurringUsecase(recurringRepo, accountRepo, categoryRepo, trxUsecase, timeoutContext)
+	rch.NewRecurringHandler(e, recurringUsecase, middl)
+
+	//Report Modules
+	reportRepo := rpr.NewMysqlReportRepository(db)
+	reportUsecase := rpu.NewReportUsecase(reportRepo, rateRepo, loanRepo, investmentRepo, securityRepo, trxRepo, recurringUsecase, timeoutContext)
+	rph.NewReportHandler(e, reportUsecase, middl)
+
+	recurringInterval, err := time.ParseDuration(os.Getenv(`RECURRING_INTERVAL`))
+
+	if err != nil {
+		recurringInterval = time.Minute
+	}
+
+	go rcs.NewRecurringScheduler(recurringUsecase, recurringInterval).Start(context.Background())
+
+	trashRetention, err := time.ParseDuration(os.Getenv(`TRASH_RETENTION`))
+
+	if err != nil {
+		trashRetention = 30 * 24 * time.Hour
+	}
+
+	go tss.NewTrashScheduler(trashUsecase, trashRetention, time.Hour).Start(context.Background())
+
 	log.Fatal(e.Start(os.Getenv(`PORT`)))
 }
+
+// newStorage picks where attachments are kept: the local STORAGE_DIR by default, or an S3 compatible bucket when
+// STORAGE_DRIVER is s3.
+func newStorage() (storage.Storage, error) {
+	if os.Getenv(`STORAGE_DRIVER`) == `s3` {
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:  os.Getenv(`S3_ENDPOINT`),
+			Region:    os.Getenv(`S3_REGION`),
+			Bucket:    os.Getenv(`S3_BUCKET`),
+			AccessKey: os.Getenv(`S3_ACCESS_KEY`),
+			SecretKey: os.Getenv(`S3_SECRET_KEY`),
+		}), nil
+	}
+
+	dir := os.Getenv(`STORAGE_DIR`)
+
+	if dir == "" {
+		dir = "storage"
+	}
+
+	return storage.NewLocalStorage(dir)
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/modules/user"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
 )
@@ -12,11 +14,14 @@ import (
 var signingKey = []byte("aqOeh4ck3R")
 
 type Middleware struct {
+	userRepo user.Repository
 }
 
 // InitMiddleware intialize the middleware
-func InitMiddleware() *Middleware {
-	return &Middleware{}
+func InitMiddleware(u user.Repository) *Middleware {
+	return &Middleware{
+		userRepo: u,
+	}
 }
 
 func (m *Middleware) Authorize(next echo.HandlerFunc) echo.HandlerFunc {
@@ -59,6 +64,28 @@ func (m *Middleware) Authorize(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// Admin only lets through users with the admin role. It must run after Authorize. The role is read from the
+// users table on every request rather than from the token, so that a revoked role takes effect at once.
+func (m *Middleware) Admin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		u, err := m.userRepo.FetchById(c.Request().Context(), c.Get("userId").(int))
+
+		if err != nil && err != helpers.ErrNotFound {
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": helpers.ErrInternalServerError.Error(),
+			})
+		}
+
+		if err != nil || u.Role != "admin" {
+			return c.JSON(http.StatusForbidden, echo.Map{
+				"message": "Forbidden",
+			})
+		}
+
+		return next(c)
+	}
+}
+
 // CORS will handle the CORS middleware
 func (m *Middleware) CORS(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -66,3 +93,20 @@ func (m *Middleware) CORS(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// RequestID tags every request with an id, taken from the X-Request-ID header when the client sends a usable one,
+// echoes it back in the response and makes it available to the usecases through the request context.
+func (m *Middleware) RequestID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Request().Header.Get(echo.HeaderXRequestID)
+
+		if id == "" || len(id) > 64 || strings.IndexFunc(id, func(r rune) bool { return r <= ' ' || r > '~' }) >= 0 {
+			id = helpers.NewRequestID()
+		}
+
+		c.Response().Header().Set(echo.HeaderXRequestID, id)
+		c.SetRequest(c.Request().WithContext(helpers.WithRequestID(c.Request().Context(), id)))
+
+		return next(c)
+	}
+}
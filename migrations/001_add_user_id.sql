@@ -0,0 +1,12 @@
+-- Scope accounts and transactions to the user that owns them.
+-- Existing rows are assigned to the first registered user; without any user they keep user_id 0.
+
+ALTER TABLE `accounts` ADD COLUMN `user_id` int(11) NOT NULL DEFAULT '0' AFTER `id`;
+ALTER TABLE `accounts` ADD KEY `idx_accounts_user_id` (`user_id`);
+
+ALTER TABLE `transactions` ADD COLUMN `user_id` int(11) NOT NULL DEFAULT '0' AFTER `id`;
+ALTER TABLE `transactions` ADD KEY `idx_transactions_user_id` (`user_id`);
+
+UPDATE `accounts` SET `user_id` = (SELECT MIN(`id`) FROM `users`) WHERE EXISTS (SELECT 1 FROM `users`);
+
+UPDATE `transactions` t JOIN `accounts` a ON t.`account_id` = a.`id` SET t.`user_id` = a.`user_id`;
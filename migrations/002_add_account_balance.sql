@@ -0,0 +1,9 @@
+-- Keep a current balance per account, seeded from the existing active transactions.
+
+ALTER TABLE `accounts` ADD COLUMN `balance` double NOT NULL DEFAULT '0' AFTER `description`;
+
+UPDATE `accounts` a SET a.`balance` = (
+  SELECT COALESCE(SUM(t.`amount_in` - t.`amount_out`), 0) FROM `transactions` t WHERE t.`account_id` = a.`id` AND t.`status` = 1
+);
+
+ALTER TABLE `transactions` ADD KEY `idx_transactions_account_created` (`account_id`, `created_at`);
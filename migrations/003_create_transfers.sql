@@ -0,0 +1,20 @@
+-- Transfers move money between two accounts of the same user as a linked out/in pair of transactions.
+
+CREATE TABLE `transfers` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `from_account_id` int(11) NOT NULL,
+  `to_account_id` int(11) NOT NULL,
+  `amount` double NOT NULL DEFAULT '0',
+  `description` text NOT NULL,
+  `out_trx_id` int(11) NOT NULL DEFAULT '0',
+  `in_trx_id` int(11) NOT NULL DEFAULT '0',
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_transfers_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+ALTER TABLE `transactions` ADD COLUMN `transfer_id` int(11) DEFAULT NULL AFTER `account_id`;
+ALTER TABLE `transactions` ADD KEY `idx_transactions_transfer_id` (`transfer_id`);
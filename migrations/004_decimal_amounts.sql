@@ -0,0 +1,35 @@
+-- Store money as exact DECIMAL(19,4) instead of double.
+--
+-- Amounts entered through the API never carried more than a few decimal places, so every stored double should
+-- convert exactly. The check below stops the migration before anything is altered when one does not: a value with
+-- more than four decimals, or beyond the 922337203685477 the API can read back, makes it insert NULL into a NOT
+-- NULL column, which fails under strict mode. List the offending rows with the same conditions, correct them by
+-- hand and run the migration again.
+
+SET SESSION sql_mode = CONCAT_WS(',', NULLIF(@@SESSION.sql_mode, ''), 'STRICT_ALL_TABLES');
+
+CREATE TEMPORARY TABLE `decimal_amounts_check` (`inexact` int(11) NOT NULL);
+
+INSERT INTO `decimal_amounts_check` (`inexact`)
+SELECT IF(COUNT(*) = 0, 0, NULL) FROM (
+  SELECT `id` FROM `transactions`
+  WHERE ROUND(`amount_in`, 4) <> `amount_in` OR ROUND(`amount_out`, 4) <> `amount_out`
+    OR ABS(`amount_in`) > 922337203685477 OR ABS(`amount_out`) > 922337203685477
+  UNION ALL
+  SELECT `id` FROM `transfers` WHERE ROUND(`amount`, 4) <> `amount` OR ABS(`amount`) > 922337203685477
+) inexact;
+
+DROP TEMPORARY TABLE `decimal_amounts_check`;
+
+ALTER TABLE `transactions`
+  MODIFY `amount_in` decimal(19,4) NOT NULL DEFAULT '0',
+  MODIFY `amount_out` decimal(19,4) NOT NULL DEFAULT '0';
+
+ALTER TABLE `transfers` MODIFY `amount` decimal(19,4) NOT NULL DEFAULT '0';
+
+-- Rebuild balances from the converted rows rather than converting the accumulated double.
+ALTER TABLE `accounts` MODIFY `balance` decimal(19,4) NOT NULL DEFAULT '0';
+
+UPDATE `accounts` a SET a.`balance` = (
+  SELECT COALESCE(SUM(t.`amount_in` - t.`amount_out`), 0) FROM `transactions` t WHERE t.`account_id` = a.`id` AND t.`status` = 1
+);
@@ -0,0 +1,19 @@
+-- Accounts hold a single currency that their transactions inherit; existing accounts are in IDR.
+
+ALTER TABLE `accounts` ADD COLUMN `currency` char(3) NOT NULL DEFAULT 'IDR' AFTER `description`;
+
+-- One unit of base_currency is worth `rate` units of quote_currency from rate_date until the next rate of the pair.
+
+CREATE TABLE `exchange_rates` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `base_currency` char(3) NOT NULL,
+  `quote_currency` char(3) NOT NULL,
+  `rate` decimal(20,8) NOT NULL,
+  `rate_date` date NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_exchange_rates_pair_date` (`user_id`, `base_currency`, `quote_currency`, `rate_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,18 @@
+-- Per-user hierarchical categories for transactions. Users registered before this migration start without
+-- categories and can create their own.
+
+CREATE TABLE `categories` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `parent_id` int(11) DEFAULT NULL,
+  `name` varchar(55) NOT NULL,
+  `type` varchar(55) NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_categories_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+ALTER TABLE `transactions` ADD COLUMN `category_id` int(11) DEFAULT NULL AFTER `account_id`;
+ALTER TABLE `transactions` ADD KEY `idx_transactions_category_id` (`category_id`);
@@ -0,0 +1,19 @@
+-- Budgets cap the spending of a category per period. Custom periods repeat the range between start_date and
+-- end_date; threshold is the percentage of the budget that flags it.
+
+CREATE TABLE `budgets` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `category_id` int(11) NOT NULL,
+  `amount` decimal(19,4) NOT NULL,
+  `period` varchar(55) NOT NULL,
+  `start_date` date NOT NULL,
+  `end_date` date DEFAULT NULL,
+  `rollover` tinyint(1) NOT NULL DEFAULT '0',
+  `threshold` int(11) NOT NULL DEFAULT '80',
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_budgets_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,41 @@
+-- Recurring templates post a transaction on every date matched by their rule. next_date is NULL once a template
+-- has ended.
+
+CREATE TABLE `recurrings` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `category_id` int(11) DEFAULT NULL,
+  `name` varchar(55) NOT NULL,
+  `type` varchar(55) NOT NULL,
+  `description` text NOT NULL,
+  `amount` decimal(19,4) NOT NULL,
+  `frequency` varchar(55) NOT NULL,
+  `interval_count` int(11) NOT NULL DEFAULT '1',
+  `cron` varchar(100) NOT NULL DEFAULT '',
+  `start_date` date NOT NULL,
+  `end_date` date DEFAULT NULL,
+  `max_occurrences` int(11) NOT NULL DEFAULT '0',
+  `occurrences` int(11) NOT NULL DEFAULT '0',
+  `last_date` date DEFAULT NULL,
+  `next_date` date DEFAULT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_recurrings_user_id` (`user_id`),
+  KEY `idx_recurrings_next_date` (`status`, `next_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+-- Every posted occurrence is claimed here first; the unique key keeps an occurrence from being posted twice,
+-- even when several instances of the scheduler run at the same time.
+
+CREATE TABLE `recurring_occurrences` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `recurring_id` int(11) NOT NULL,
+  `occurrence_date` date NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_recurring_occurrence` (`recurring_id`, `occurrence_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
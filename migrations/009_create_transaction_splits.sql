@@ -0,0 +1,13 @@
+-- Splits divide the amount of a transaction between categories. Amounts are positive and follow the direction
+-- of their transaction; the splits of a transaction always add up to its amount.
+
+CREATE TABLE `transaction_splits` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `transaction_id` int(11) NOT NULL,
+  `category_id` int(11) DEFAULT NULL,
+  `amount` decimal(19,4) NOT NULL,
+  `memo` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `idx_transaction_splits_transaction_id` (`transaction_id`),
+  KEY `idx_transaction_splits_category_id` (`category_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,15 @@
+-- Attachments keep files next to transactions. The file itself lives in the configured storage under
+-- storage_key; rows are removed together with the file.
+
+CREATE TABLE `attachments` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `transaction_id` int(11) NOT NULL,
+  `file_name` varchar(255) NOT NULL,
+  `content_type` varchar(100) NOT NULL,
+  `size` bigint(20) NOT NULL,
+  `storage_key` varchar(255) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_attachments_transaction_id` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,23 @@
+-- Import profiles map the columns of a bank's CSV statement to transactions. Columns count from 1 and 0 leaves
+-- a column unused.
+
+CREATE TABLE `import_profiles` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `name` varchar(55) NOT NULL,
+  `delimiter` varchar(1) NOT NULL DEFAULT ',',
+  `skip_rows` int(11) NOT NULL DEFAULT '0',
+  `date_column` int(11) NOT NULL,
+  `date_format` varchar(55) NOT NULL,
+  `description_column` int(11) NOT NULL DEFAULT '0',
+  `amount_column` int(11) NOT NULL DEFAULT '0',
+  `amount_sign` varchar(55) NOT NULL DEFAULT 'negative-out',
+  `debit_column` int(11) NOT NULL DEFAULT '0',
+  `credit_column` int(11) NOT NULL DEFAULT '0',
+  `decimal_separator` varchar(1) NOT NULL DEFAULT '.',
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_import_profiles_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,5 @@
+-- fitid keeps the FITID of transactions imported from OFX statements, so that importing an overlapping
+-- statement again skips what is already there. Transactions entered by hand leave it NULL.
+
+ALTER TABLE `transactions` ADD COLUMN `fitid` varchar(255) DEFAULT NULL AFTER `transfer_id`;
+ALTER TABLE `transactions` ADD UNIQUE KEY `uniq_transactions_account_fitid` (`account_id`, `fitid`);
@@ -0,0 +1,20 @@
+-- cleared records the reconciliation state of a transaction: 0 uncleared, 1 cleared (ticked against a bank
+-- statement) and 2 reconciled, once the reconciliation named by reconciliation_id has been finalized.
+-- Reconciled transactions can no longer be changed or deleted.
+
+ALTER TABLE `transactions` ADD COLUMN `cleared` int(11) NOT NULL DEFAULT '0' AFTER `fitid`;
+ALTER TABLE `transactions` ADD COLUMN `reconciliation_id` int(11) DEFAULT NULL AFTER `cleared`;
+
+CREATE TABLE `reconciliations` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `statement_date` date NOT NULL,
+  `statement_balance` decimal(19,4) NOT NULL,
+  `finalized_at` timestamp NULL DEFAULT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_reconciliations_account_id` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
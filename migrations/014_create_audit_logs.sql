@@ -0,0 +1,17 @@
+-- The audit log keeps a row for every change made to accounts and transactions, with the record before and after
+-- the change as JSON. Rows are only ever inserted; user_id owns the record and actor_id made the change.
+
+CREATE TABLE `audit_logs` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `actor_id` int(11) NOT NULL,
+  `entity` varchar(55) NOT NULL,
+  `entity_id` int(11) NOT NULL,
+  `action` varchar(55) NOT NULL,
+  `before_data` mediumtext,
+  `after_data` mediumtext,
+  `request_id` varchar(64) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_audit_logs_entity` (`user_id`, `entity`, `entity_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,11 @@
+-- Deleted accounts and transactions stay in the trash, where they can be restored, until deleted_at is older than
+-- the retention and the purge job removes them for good. Deleting an account moves its transactions to the trash
+-- with it; deleted_with_account names that account so that restoring it brings back exactly those transactions.
+
+ALTER TABLE `accounts` ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL AFTER `status`;
+UPDATE `accounts` SET `deleted_at` = `updated_at` WHERE `status` = 0;
+
+ALTER TABLE `transactions` ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL AFTER `status`;
+ALTER TABLE `transactions` ADD COLUMN `deleted_with_account` int(11) DEFAULT NULL AFTER `deleted_at`;
+UPDATE `transactions` SET `deleted_at` = `updated_at` WHERE `status` = 0;
+ALTER TABLE `transactions` ADD KEY `idx_transactions_deleted_at` (`status`, `deleted_at`);
@@ -0,0 +1,22 @@
+-- Closing a period locks every transaction of the user dated on or before close_date; mistakes found later are
+-- corrected with reversing entries, linked through reversal_of, posted in an open period. Only an admin can reopen
+-- a close, which keeps the row with who reopened it and why.
+
+CREATE TABLE `period_closes` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `close_date` date NOT NULL,
+  `closed_by` int(11) NOT NULL,
+  `reopened_by` int(11) DEFAULT NULL,
+  `reopened_at` timestamp NULL DEFAULT NULL,
+  `reopen_reason` text,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_period_closes_user_id` (`user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+ALTER TABLE `transactions` ADD COLUMN `reversal_of` int(11) DEFAULT NULL AFTER `reconciliation_id`;
+
+ALTER TABLE `users` ADD COLUMN `role` varchar(55) NOT NULL DEFAULT 'user' AFTER `name`;
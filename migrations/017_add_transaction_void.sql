@@ -0,0 +1,7 @@
+-- Voiding a transaction posts an equal and opposite entry instead of deleting it. Both rows get voided=1, which
+-- keeps the pair out of the summaries; the original names its reversal in voided_by and keeps the reason, and the
+-- reversal points back through reversal_of.
+
+ALTER TABLE `transactions` ADD COLUMN `voided` int(11) NOT NULL DEFAULT '0' AFTER `reversal_of`;
+ALTER TABLE `transactions` ADD COLUMN `voided_by` int(11) DEFAULT NULL AFTER `voided`;
+ALTER TABLE `transactions` ADD COLUMN `void_reason` varchar(255) DEFAULT NULL AFTER `voided_by`;
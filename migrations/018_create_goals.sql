@@ -0,0 +1,22 @@
+-- Savings goals track a target amount to reach by a target date through the balance of one or more linked
+-- accounts. Progress is counted in the goal currency, converting the accounts held in other currencies.
+
+CREATE TABLE `goals` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `name` varchar(55) NOT NULL,
+  `target_amount` decimal(19,4) NOT NULL,
+  `target_date` date NOT NULL,
+  `currency` char(3) NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_goals_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `goal_accounts` (
+  `goal_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  PRIMARY KEY (`goal_id`, `account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,37 @@
+-- A loan holds the terms of an account of type loan: the principal borrowed on start_date, the annual interest rate
+-- in percent, the term in months and the day of the month payments are due. Every payment posted to the account is
+-- split into interest on the outstanding principal and principal repaid, recorded in loan_payments, and lowers
+-- outstanding_principal. A payment has status 1 while it counts, 0 while its transaction is in the trash and 2 once
+-- the transaction has been voided, reversed or changed, which gives its principal back to the loan for good.
+
+CREATE TABLE `loans` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `principal` decimal(19,4) NOT NULL,
+  `interest_rate` decimal(20,8) NOT NULL,
+  `term_months` int(11) NOT NULL,
+  `payment_day` int(11) NOT NULL,
+  `start_date` date NOT NULL,
+  `outstanding_principal` decimal(19,4) NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_loans_account_id` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `loan_payments` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `loan_id` int(11) NOT NULL,
+  `transaction_id` int(11) NOT NULL,
+  `payment_date` date NOT NULL,
+  `principal` decimal(19,4) NOT NULL,
+  `interest` decimal(19,4) NOT NULL,
+  `outstanding_principal` decimal(19,4) NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_loan_payments_loan_id` (`loan_id`),
+  KEY `idx_loan_payments_transaction_id` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,53 @@
+-- Securities are the instruments traded in accounts of type investment, priced in their own currency through
+-- security_prices, posted by hand or imported from CSV. A trade buys, sells or collects a dividend on a security and
+-- posts the cash it moves as a transaction of the account, linked back through transactions.trade_id. Lots and cost
+-- basis are not stored: they are rebuilt by replaying the trades of a security in date order, and the cost and
+-- realized gain of every sell are updated whenever an earlier trade is added or removed.
+
+CREATE TABLE `securities` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `symbol` varchar(20) NOT NULL,
+  `name` varchar(55) NOT NULL,
+  `currency` char(3) NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_securities_user_id` (`user_id`, `symbol`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `security_prices` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `security_id` int(11) NOT NULL,
+  `price` decimal(19,4) NOT NULL,
+  `price_date` date NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_security_prices_date` (`security_id`, `price_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `trades` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `security_id` int(11) NOT NULL,
+  `kind` varchar(10) NOT NULL,
+  `quantity` decimal(18,8) NOT NULL DEFAULT '0',
+  `price` decimal(19,4) NOT NULL DEFAULT '0',
+  `fee` decimal(19,4) NOT NULL DEFAULT '0',
+  `amount` decimal(19,4) NOT NULL,
+  `cost_basis` varchar(10) DEFAULT NULL,
+  `cost` decimal(19,4) NOT NULL DEFAULT '0',
+  `realized_gain` decimal(19,4) NOT NULL DEFAULT '0',
+  `transaction_id` int(11) DEFAULT NULL,
+  `trade_date` date NOT NULL,
+  `status` int(11) DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_trades_account_id` (`account_id`, `security_id`, `trade_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+ALTER TABLE `transactions` ADD COLUMN `trade_id` int(11) DEFAULT NULL AFTER `transfer_id`;
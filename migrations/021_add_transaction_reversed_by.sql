@@ -0,0 +1,10 @@
+-- A transaction can be reversed only once: the original names its reversing entry in reversed_by, which is
+-- checked and set under the same lock that posts the reversal. Existing reversals are linked back here, keeping the
+-- earliest when a transaction was reversed more than once.
+
+ALTER TABLE `transactions` ADD COLUMN `reversed_by` int(11) DEFAULT NULL AFTER `reversal_of`;
+
+UPDATE `transactions` o JOIN (
+  SELECT `reversal_of`, MIN(`id`) AS `id` FROM `transactions` WHERE `reversal_of` IS NOT NULL AND `voided`=0 GROUP BY `reversal_of`
+) r ON o.`id`=r.`reversal_of`
+SET o.`reversed_by`=r.`id`;
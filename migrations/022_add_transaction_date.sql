@@ -0,0 +1,19 @@
+-- Transactions and transfers carry the date they belong to, separate from the time they were recorded. Balances
+-- over time, running balances, summaries, period closes and reports all read trx_date, so that back-dated entries
+-- and those posted by recurring templates land on the right day.
+--
+-- Rows recorded before this migration only know when they were entered: they are backfilled with the day of
+-- created_at, in the time zone of the session running the migration, which is what every report showed until
+-- now. Both legs of a transfer then take the date of the transfer itself, so the pair never falls on two days.
+
+ALTER TABLE `transactions` ADD COLUMN `trx_date` date DEFAULT NULL AFTER `amount_out`;
+UPDATE `transactions` SET `trx_date` = DATE(`created_at`);
+
+ALTER TABLE `transfers` ADD COLUMN `trx_date` date DEFAULT NULL AFTER `description`;
+UPDATE `transfers` SET `trx_date` = DATE(`created_at`);
+
+UPDATE `transactions` t JOIN `transfers` tr ON t.`transfer_id` = tr.`id` SET t.`trx_date` = tr.`trx_date`;
+
+ALTER TABLE `transactions` MODIFY `trx_date` date NOT NULL;
+ALTER TABLE `transactions` ADD KEY `idx_transactions_account_date` (`account_id`, `trx_date`);
+ALTER TABLE `transfers` MODIFY `trx_date` date NOT NULL;
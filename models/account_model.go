@@ -2,12 +2,17 @@ package models
 
 import "time"
 
+// Account holds money in one currency. DeletedAt is set while the account is in the trash.
 type Account struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name" validate:"required"`
-	Type        string    `json:"type" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          int        `json:"id"`
+	UserID      int        `json:"-"`
+	Name        string     `json:"name" validate:"required"`
+	Type        string     `json:"type" validate:"required"`
+	Description string     `json:"description" validate:"required"`
+	Currency    string     `json:"currency"`
+	Balance     Money      `json:"balance"`
+	Status      string     `json:"status"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Attachment is a file, such as a scanned receipt, kept next to a transaction.
+type Attachment struct {
+	ID            int       `json:"id"`
+	UserID        int       `json:"-"`
+	TransactionID int       `json:"transactionId"`
+	FileName      string    `json:"fileName"`
+	ContentType   string    `json:"contentType"`
+	Size          int64     `json:"size"`
+	StorageKey    string    `json:"-"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records one change to an account or transaction. Before is empty for a creation and After for a
+// deletion.
+type AuditLog struct {
+	ID        int             `json:"id"`
+	UserID    int             `json:"-"`
+	ActorID   int             `json:"actorId"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entityId"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	RequestID string          `json:"requestId,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
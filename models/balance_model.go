@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+type Balance struct {
+	AccountID int       `json:"accountId"`
+	Balance   Money     `json:"balance"`
+	AsOf      time.Time `json:"asOf"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type Budget struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"-"`
+	Category  Category   `json:"category"`
+	Amount    Money      `json:"amount"`
+	Period    string     `json:"period"`
+	StartDate time.Time  `json:"startDate"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	Rollover  bool       `json:"rollover"`
+	Threshold int        `json:"threshold"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	// Progress is filled when the budget is evaluated for a period.
+	Progress *BudgetProgress `json:"progress,omitempty"`
+}
+
+// BudgetProgress compares what was budgeted for a period with what was actually spent in it.
+type BudgetProgress struct {
+	PeriodStart   time.Time `json:"periodStart"`
+	PeriodEnd     time.Time `json:"periodEnd"`
+	Carried       Money     `json:"carried"`
+	Budgeted      Money     `json:"budgeted"`
+	Actual        Money     `json:"actual"`
+	Remaining     Money     `json:"remaining"`
+	OverThreshold bool      `json:"overThreshold"`
+	OverSpent     bool      `json:"overSpent"`
+}
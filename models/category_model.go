@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Category struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	ParentID  int       `json:"parentId"`
+	Name      string    `json:"name" validate:"required"`
+	Type      string    `json:"type" validate:"required,oneof=in out"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateScale is the number of decimal places kept by Rate, matching the DECIMAL(20,8) rate column.
+const RateScale = 8
+
+const rateUnit = 100000000
+
+// Rate is an exact exchange rate, stored as a whole number of 10^-8 units.
+type Rate int64
+
+type ExchangeRate struct {
+	ID            int       `json:"id"`
+	UserID        int       `json:"-"`
+	BaseCurrency  string    `json:"baseCurrency"`
+	QuoteCurrency string    `json:"quoteCurrency"`
+	Rate          Rate      `json:"rate"`
+	Date          time.Time `json:"date"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// ParseRate parses a decimal string such as "15250.5" and rejects rates with more than RateScale decimal places.
+func ParseRate(s string) (Rate, error) {
+	units, err := parseFixed(s, RateScale, false)
+
+	return Rate(units), err
+}
+
+func (r Rate) String() string {
+	s := fmt.Sprintf("%d.%08d", int64(r)/rateUnit, int64(r)%rateUnit)
+
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	v, err := ParseRate(s)
+
+	if err != nil {
+		return err
+	}
+
+	*r = v
+
+	return nil
+}
+
+func (r *Rate) Scan(src interface{}) error {
+	var s string
+
+	switch value := src.(type) {
+	case []byte:
+		s = string(value)
+	case string:
+		s = value
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+
+	units, err := parseFixed(s, RateScale, true)
+
+	if err != nil {
+		return err
+	}
+
+	*r = Rate(units)
+
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d.%08d", int64(r)/rateUnit, int64(r)%rateUnit), nil
+}
+
+// Mul converts an amount in the base currency of r to its quote currency.
+func (m Money) Mul(r Rate) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(r)))
+
+	return Money(divRound(product, big.NewInt(rateUnit)))
+}
+
+// Div converts an amount in the quote currency of r back to its base currency.
+func (m Money) Div(r Rate) Money {
+	scaled := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(rateUnit))
+
+	return Money(divRound(scaled, big.NewInt(int64(r))))
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Goal is a savings target reached through the balance of its linked accounts, counted in Currency.
+type Goal struct {
+	ID           int        `json:"id"`
+	UserID       int        `json:"-"`
+	Name         string     `json:"name"`
+	TargetAmount Money      `json:"targetAmount"`
+	TargetDate   time.Time  `json:"targetDate"`
+	Currency     string     `json:"currency"`
+	Accounts     []*Account `json:"accounts"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	// Progress is filled when the goal is evaluated on a date.
+	Progress *GoalProgress `json:"progress,omitempty"`
+}
+
+// GoalProgress measures a goal on Date. Saved is the combined balance of the linked accounts, RequiredMonthly what
+// still has to be put aside in each of the MonthsLeft months to reach the target on time, and TrailingMonthly the
+// average net contribution of the last complete months, from which ProjectedDate extrapolates when the target will
+// be reached. ProjectedDate is left empty when the trailing contribution is not positive. State is achieved,
+// on_track, behind or overdue.
+type GoalProgress struct {
+	Date            time.Time  `json:"date"`
+	Saved           Money      `json:"saved"`
+	Remaining       Money      `json:"remaining"`
+	Percent         float64    `json:"percent"`
+	MonthsLeft      int        `json:"monthsLeft"`
+	RequiredMonthly Money      `json:"requiredMonthly"`
+	TrailingMonthly Money      `json:"trailingMonthly"`
+	ProjectedDate   *time.Time `json:"projectedDate,omitempty"`
+	State           string     `json:"state"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ImportError describes why a row of an imported file was rejected. Row counts from 1 and includes the header.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportProfile describes how the columns of a bank's CSV statement map to transactions. Columns count from 1
+// and 0 leaves a column unused. Amounts come either from one signed column or from separate debit and credit
+// columns.
+type ImportProfile struct {
+	ID                int       `json:"id"`
+	UserID            int       `json:"-"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	SkipRows          int       `json:"skipRows"`
+	DateColumn        int       `json:"dateColumn"`
+	DateFormat        string    `json:"dateFormat"`
+	DescriptionColumn int       `json:"descriptionColumn"`
+	AmountColumn      int       `json:"amountColumn"`
+	AmountSign        string    `json:"amountSign"`
+	DebitColumn       int       `json:"debitColumn"`
+	CreditColumn      int       `json:"creditColumn"`
+	DecimalSeparator  string    `json:"decimalSeparator"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// ImportResult lists the transactions parsed from a statement and the rows that were rejected. The
+// transactions are only stored when the import is committed. Duplicates counts OFX entries skipped because
+// their FITID was imported before, and Balance checks the statement's ledger balance against ours.
+type ImportResult struct {
+	Transactions []*Transaction `json:"transactions"`
+	Errors       []*ImportError `json:"errors"`
+	Imported     int            `json:"imported"`
+	Committed    bool           `json:"committed"`
+	Duplicates   int            `json:"duplicates,omitempty"`
+	Balance      *ImportBalance `json:"balance,omitempty"`
+}
+
+// ImportBalance compares the ledger balance reported by a statement with the balance of the account on the
+// same day, including the transactions of the import.
+type ImportBalance struct {
+	AsOf            time.Time `json:"asOf"`
+	LedgerBalance   Money     `json:"ledgerBalance"`
+	ComputedBalance Money     `json:"computedBalance"`
+	Difference      Money     `json:"difference"`
+}
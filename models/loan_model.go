@@ -0,0 +1,58 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// Loan holds the terms of an account of type loan. InterestRate is the annual rate in percent and PaymentDay the day
+// of the month installments are due, starting the month after StartDate. OutstandingPrincipal goes down with the
+// principal part of every payment posted to the account.
+type Loan struct {
+	ID                   int       `json:"id"`
+	UserID               int       `json:"-"`
+	AccountID            int       `json:"accountId"`
+	Principal            Money     `json:"principal"`
+	InterestRate         Rate      `json:"interestRate"`
+	TermMonths           int       `json:"termMonths"`
+	PaymentDay           int       `json:"paymentDay"`
+	StartDate            time.Time `json:"startDate"`
+	OutstandingPrincipal Money     `json:"outstandingPrincipal"`
+	Status               string    `json:"status"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+	// Installment and Payments are filled when the loan is fetched by its account.
+	Installment Money          `json:"installment,omitempty"`
+	Payments    []*LoanPayment `json:"payments,omitempty"`
+}
+
+// MonthlyInterest returns one month of interest on balance at the annual rate of the loan, rounded half away from
+// zero.
+func (l *Loan) MonthlyInterest(balance Money) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(balance)), big.NewInt(int64(l.InterestRate)))
+
+	return Money(divRound(product, big.NewInt(rateUnit*1200)))
+}
+
+// LoanPayment is the split of a payment transaction posted to a loan account. OutstandingPrincipal is what was
+// left to repay once the payment was applied.
+type LoanPayment struct {
+	ID                   int       `json:"id"`
+	LoanID               int       `json:"loanId"`
+	TransactionID        int       `json:"transactionId"`
+	Date                 time.Time `json:"date"`
+	Principal            Money     `json:"principal"`
+	Interest             Money     `json:"interest"`
+	OutstandingPrincipal Money     `json:"outstandingPrincipal"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+// LoanInstallment is one line of an amortization schedule. Balance is the principal left after the installment.
+type LoanInstallment struct {
+	Number    int       `json:"number"`
+	Date      time.Time `json:"date"`
+	Payment   Money     `json:"payment"`
+	Principal Money     `json:"principal"`
+	Interest  Money     `json:"interest"`
+	Balance   Money     `json:"balance"`
+}
@@ -0,0 +1,228 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale is the number of decimal places kept by Money, matching the scale of the DECIMAL(19,4) amount columns.
+const MoneyScale = 4
+
+const moneyUnit = 10000
+
+var (
+	// ErrInvalidMoney will throw if an amount is not a plain decimal number
+	ErrInvalidMoney = errors.New("Amount is not a valid decimal number")
+	// ErrMoneyScale will throw if an amount has more decimal places than MoneyScale
+	ErrMoneyScale = fmt.Errorf("Amount has more than %d decimal places", MoneyScale)
+	// ErrMoneyRange will throw if an amount does not fit in a Money
+	ErrMoneyRange = errors.New("Amount is out of range")
+)
+
+// Money is an exact decimal amount, stored as a whole number of 1/10000 units so sums never accumulate
+// rounding errors. It is encoded in JSON as a string and in SQL as a DECIMAL. An int64 of 1/10000 units reaches
+// about 922 trillion, short of the 15 integer digits of DECIMAL(19,4); larger amounts fail with ErrMoneyRange.
+type Money int64
+
+// ParseMoney parses a decimal string such as "-1250.5" and rejects amounts with more than MoneyScale decimal places.
+func ParseMoney(s string) (Money, error) {
+	units, err := parseFixed(s, MoneyScale, false)
+
+	return Money(units), err
+}
+
+// parseFixed parses s into a whole number of 10^-scale units, rounding half away from zero instead of failing
+// when round is set and s has too many decimal places.
+func parseFixed(s string, scale int, round bool) (int64, error) {
+	s = strings.TrimSpace(s)
+
+	negative := false
+
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, frac := s, ""
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidMoney
+	}
+
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, ErrInvalidMoney
+	}
+
+	roundUp := false
+
+	if len(frac) > scale {
+		if !round && strings.TrimRight(frac[scale:], "0") != "" {
+			return 0, ErrMoneyScale
+		}
+
+		roundUp = frac[scale] >= '5'
+		frac = frac[:scale]
+	}
+
+	frac = frac + strings.Repeat("0", scale-len(frac))
+
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+
+	if err != nil {
+		return 0, ErrMoneyRange
+	}
+
+	if roundUp {
+		if units == math.MaxInt64 {
+			return 0, ErrMoneyRange
+		}
+
+		units++
+	}
+
+	if negative {
+		units = -units
+	}
+
+	return units, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount with at least two and at most MoneyScale decimal places.
+func (m Money) String() string {
+	s := m.fixed()
+
+	for strings.HasSuffix(s, "0") && len(s)-strings.IndexByte(s, '.') > 3 {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+// fixed formats the amount with exactly MoneyScale decimal places.
+func (m Money) fixed() string {
+	units := int64(m)
+	sign := ""
+
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	return fmt.Sprintf("%s%d.%04d", sign, units/moneyUnit, units%moneyUnit)
+}
+
+// DivRound divides the amount by n, rounding half away from zero.
+func (m Money) DivRound(n int64) Money {
+	return Money(divRound(big.NewInt(int64(m)), big.NewInt(n)))
+}
+
+// divRound divides a by b, rounding half away from zero, and returns the result as an int64.
+func divRound(a *big.Int, b *big.Int) int64 {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+
+	if new(big.Int).Abs(new(big.Int).Mul(r, big.NewInt(2))).Cmp(new(big.Int).Abs(b)) >= 0 {
+		if (a.Sign() < 0) != (b.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q.Int64()
+}
+
+// Float64 returns the nearest float64, for display and ratios only.
+func (m Money) Float64() float64 {
+	return float64(m) / moneyUnit
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts both "12.50" and 12.50, reading the digits as written instead of through a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+
+	if s == "null" {
+		*m = 0
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	v, err := ParseMoney(s)
+
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+// Scan reads DECIMAL columns, rounding aggregates such as AVG that come back with a wider scale.
+func (m *Money) Scan(src interface{}) error {
+	var (
+		v   Money
+		err error
+	)
+
+	switch value := src.(type) {
+	case nil:
+		v = 0
+	case []byte:
+		var units int64
+		units, err = parseFixed(string(value), MoneyScale, true)
+		v = Money(units)
+	case string:
+		var units int64
+		units, err = parseFixed(value, MoneyScale, true)
+		v = Money(units)
+	case int64:
+		if value > math.MaxInt64/moneyUnit || value < math.MinInt64/moneyUnit {
+			err = ErrMoneyRange
+		}
+
+		v = Money(value * moneyUnit)
+	default:
+		err = fmt.Errorf("cannot scan %T into Money", src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.fixed(), nil
+}
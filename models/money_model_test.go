@@ -0,0 +1,104 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseFixed(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		scale int
+		round bool
+		want  int64
+		err   error
+	}{
+		{name: "whole", in: "12", scale: 4, want: 120000},
+		{name: "fraction", in: "12.5", scale: 4, want: 125000},
+		{name: "leading dot", in: ".25", scale: 4, want: 2500},
+		{name: "trailing dot", in: "3.", scale: 4, want: 30000},
+		{name: "negative", in: "-1250.5", scale: 4, want: -12505000},
+		{name: "plus sign", in: "+7", scale: 4, want: 70000},
+		{name: "spaces", in: " 1.5 ", scale: 4, want: 15000},
+		{name: "extra zero decimals", in: "1.250000", scale: 4, want: 12500},
+		{name: "too many decimals", in: "1.23456", scale: 4, err: ErrMoneyScale},
+		{name: "round half up", in: "1.23455", scale: 4, round: true, want: 12346},
+		{name: "round down", in: "1.23454", scale: 4, round: true, want: 12345},
+		{name: "round half away from zero", in: "-1.23455", scale: 4, round: true, want: -12346},
+		{name: "round carries", in: "0.99995", scale: 4, round: true, want: 10000},
+		{name: "quantity scale", in: "0.00000001", scale: 8, want: 1},
+		{name: "empty", in: "", scale: 4, err: ErrInvalidMoney},
+		{name: "only dot", in: ".", scale: 4, err: ErrInvalidMoney},
+		{name: "letters", in: "12a", scale: 4, err: ErrInvalidMoney},
+		{name: "exponent", in: "1e3", scale: 4, err: ErrInvalidMoney},
+		{name: "largest", in: "922337203685477.5807", scale: 4, want: math.MaxInt64},
+		{name: "overflow", in: "922337203685477.5808", scale: 4, err: ErrMoneyRange},
+		{name: "overflow when rounding", in: "922337203685477.58075", scale: 4, round: true, err: ErrMoneyRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFixed(tt.in, tt.scale, tt.round)
+
+			if err != tt.err {
+				t.Fatalf("parseFixed(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("parseFixed(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 125000, want: "12.50"},
+		{in: 12345, want: "1.2345"},
+		{in: 12340, want: "1.234"},
+		{in: -5, want: "-0.0005"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		want Money
+		err  error
+	}{
+		{name: "nil", src: nil, want: 0},
+		{name: "decimal bytes", src: []byte("10.5000"), want: 105000},
+		{name: "wider scale rounds", src: "3.33335", want: 33334},
+		{name: "integer", src: int64(7), want: 70000},
+		{name: "integer overflow", src: int64(math.MaxInt64 / 1000), err: ErrMoneyRange},
+		{name: "negative integer overflow", src: int64(math.MinInt64 / 1000), err: ErrMoneyRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+
+			err := m.Scan(tt.src)
+
+			if err != tt.err {
+				t.Fatalf("Scan(%v) error = %v, want %v", tt.src, err, tt.err)
+			}
+
+			if err == nil && m != tt.want {
+				t.Errorf("Scan(%v) = %s, want %s", tt.src, m, tt.want)
+			}
+		})
+	}
+}
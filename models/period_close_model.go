@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PeriodClose locks every transaction of a user dated on or before CloseDate. State is closed, or reopened once
+// an admin has lifted it, in which case ReopenedBy, ReopenedAt and ReopenReason say who did it and why.
+type PeriodClose struct {
+	ID           int        `json:"id"`
+	UserID       int        `json:"-"`
+	CloseDate    time.Time  `json:"closeDate"`
+	ClosedBy     int        `json:"closedBy"`
+	State        string     `json:"state"`
+	ReopenedBy   int        `json:"reopenedBy,omitempty"`
+	ReopenedAt   *time.Time `json:"reopenedAt,omitempty"`
+	ReopenReason string     `json:"reopenReason,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Reconciliation compares an account with a bank statement. The cleared balance sums every cleared or reconciled
+// transaction dated on or before the statement date; the session can be finalized once it equals the statement
+// balance. State is open or finalized.
+type Reconciliation struct {
+	ID               int            `json:"id"`
+	UserID           int            `json:"-"`
+	AccountID        int            `json:"accountId"`
+	StatementDate    time.Time      `json:"statementDate"`
+	StatementBalance Money          `json:"statementBalance"`
+	ClearedBalance   Money          `json:"clearedBalance"`
+	Difference       Money          `json:"difference"`
+	State            string         `json:"state"`
+	FinalizedAt      *time.Time     `json:"finalizedAt,omitempty"`
+	Transactions     []*Transaction `json:"transactions,omitempty"`
+	Status           string         `json:"status"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Recurring is a template that posts a transaction on every date matched by its rule.
+type Recurring struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"-"`
+	Account     Account   `json:"account"`
+	Category    *Category `json:"category,omitempty"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Amount      Money     `json:"amount"`
+	// Frequency is daily, weekly, monthly or cron. Interval repeats the first three every n days, weeks or
+	// months counted from StartDate; Cron holds a "day-of-month month day-of-week" rule.
+	Frequency      string     `json:"frequency"`
+	Interval       int        `json:"interval"`
+	Cron           string     `json:"cron,omitempty"`
+	StartDate      time.Time  `json:"startDate"`
+	EndDate        *time.Time `json:"endDate,omitempty"`
+	MaxOccurrences int        `json:"maxOccurrences,omitempty"`
+	Occurrences    int        `json:"occurrences"`
+	LastDate       *time.Time `json:"lastDate,omitempty"`
+	// NextDate is empty once the template has ended.
+	NextDate  *time.Time `json:"nextDate,omitempty"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// NetWorthPoint is what a user owns and owes at the end of Date, converted to the report currency. Liabilities
+// is the amount owed as a positive figure, so NetWorth is Assets minus Liabilities.
+type NetWorthPoint struct {
+	Date        time.Time `json:"date"`
+	Assets      Money     `json:"assets"`
+	Liabilities Money     `json:"liabilities"`
+	NetWorth    Money     `json:"netWorth"`
+}
+
+// NetWorth follows the net worth of a user from From to To, with a point at the end of every interval.
+type NetWorth struct {
+	Currency string           `json:"currency"`
+	Interval string           `json:"interval"`
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Points   []*NetWorthPoint `json:"points"`
+}
+
+// ForecastDay is the money an account is projected to take in and pay out on Date and the balance it is left with.
+type ForecastDay struct {
+	Date    time.Time `json:"date"`
+	In      Money     `json:"in"`
+	Out     Money     `json:"out"`
+	Balance Money     `json:"balance"`
+}
+
+// Forecast projects the balance of an account over the days following Date, starting from its Balance at the end
+// of Date. Every day takes in BaselineIn and pays out BaselineOut, the daily averages of its recent history, on top
+// of the transactions already dated that day and those its recurring templates will post. NegativeOn is the first
+// day an asset account is projected to be overdrawn.
+type Forecast struct {
+	Account     Account        `json:"account"`
+	Date        time.Time      `json:"date"`
+	Balance     Money          `json:"balance"`
+	BaselineIn  Money          `json:"baselineIn"`
+	BaselineOut Money          `json:"baselineOut"`
+	NegativeOn  *time.Time     `json:"negativeOn,omitempty"`
+	Days        []*ForecastDay `json:"days"`
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuantityScale is the number of decimal places kept by Quantity, matching the DECIMAL(18,8) quantity column.
+const QuantityScale = 8
+
+const quantityUnit = 100000000
+
+var (
+	// ErrQuantityScale will throw if a quantity has more decimal places than QuantityScale
+	ErrQuantityScale = fmt.Errorf("Quantity has more than %d decimal places", QuantityScale)
+	// ErrQuantityRange will throw if a quantity does not fit in a Quantity
+	ErrQuantityRange = errors.New("Quantity is out of range")
+)
+
+// Quantity is an exact number of units of a security, stored as a whole number of 10^-8 units so fractional
+// shares add up exactly. An int64 of 10^-8 units reaches about 92 billion, which covers the 10 integer digits of
+// DECIMAL(18,8).
+type Quantity int64
+
+// ParseQuantity parses a decimal string such as "12.5" and rejects quantities with more than QuantityScale decimal
+// places or beyond the range of Quantity.
+func ParseQuantity(s string) (Quantity, error) {
+	units, err := parseFixed(s, QuantityScale, false)
+
+	switch err {
+	case ErrMoneyScale:
+		return 0, ErrQuantityScale
+	case ErrMoneyRange:
+		return 0, ErrQuantityRange
+	}
+
+	return Quantity(units), err
+}
+
+func (q Quantity) String() string {
+	units := int64(q)
+	sign := ""
+
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	s := fmt.Sprintf("%s%d.%08d", sign, units/quantityUnit, units%quantityUnit)
+
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(q.String())), nil
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := string(data)
+
+	if s == "null" {
+		*q = 0
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	v, err := ParseQuantity(s)
+
+	if err != nil {
+		return err
+	}
+
+	*q = v
+
+	return nil
+}
+
+func (q *Quantity) Scan(src interface{}) error {
+	var s string
+
+	switch value := src.(type) {
+	case []byte:
+		s = string(value)
+	case string:
+		s = value
+	default:
+		return fmt.Errorf("cannot scan %T into Quantity", src)
+	}
+
+	units, err := parseFixed(s, QuantityScale, true)
+
+	if err == ErrMoneyRange {
+		return ErrQuantityRange
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*q = Quantity(units)
+
+	return nil
+}
+
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
+
+// MulQuantity returns the value of q units priced at m each.
+func (m Money) MulQuantity(q Quantity) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(q)))
+
+	return Money(divRound(product, big.NewInt(quantityUnit)))
+}
+
+// Prorate returns the share of m that part units carry out of whole units, such as the cost of selling part of a
+// lot.
+func (m Money) Prorate(part Quantity, whole Quantity) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(part)))
+
+	return Money(divRound(product, big.NewInt(int64(whole))))
+}
+
+// Security is a stock, fund or other instrument traded in investment accounts, priced in Currency.
+type Security struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SecurityPrice is the price of one unit of a security on Date, posted by hand or imported.
+type SecurityPrice struct {
+	ID         int       `json:"id"`
+	SecurityID int       `json:"securityId"`
+	Price      Money     `json:"price"`
+	Date       time.Time `json:"date"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
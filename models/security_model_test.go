@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Quantity
+		err  error
+	}{
+		{in: "12.5", want: 1250000000},
+		{in: "0.00000001", want: 1},
+		{in: "1.000000001", err: ErrQuantityScale},
+		{in: "9999999999.99999999", want: 999999999999999999},
+		{in: "92233720368.54775808", err: ErrQuantityRange},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseQuantity(tt.in)
+
+		if err != tt.err {
+			t.Fatalf("ParseQuantity(%q) error = %v, want %v", tt.in, err, tt.err)
+		}
+
+		if err == nil && got != tt.want {
+			t.Errorf("ParseQuantity(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
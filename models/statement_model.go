@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Statement is the activity of one account during a calendar month. The totals and averages cover the transactions
+// the monthly transaction summary counts, leaving out transfer legs and void or reversal pairs, and AverageIn and
+// AverageOut average their non-zero amounts the same way; the closing balance still moves with every transaction.
+type Statement struct {
+	Account        Account        `json:"account"`
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	OpeningBalance Money          `json:"openingBalance"`
+	ClosingBalance Money          `json:"closingBalance"`
+	TotalIn        Money          `json:"totalIn"`
+	TotalOut       Money          `json:"totalOut"`
+	AverageIn      Money          `json:"averageIn"`
+	AverageOut     Money          `json:"averageOut"`
+	Transactions   []*Transaction `json:"transactions"`
+	GeneratedAt    time.Time      `json:"generatedAt"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// Trade is a buy, sell or dividend of a security in an investment account. Amount is the cash it moves through
+// the account, posted as the transaction TransactionID: the price of the units plus the fee for a buy, minus the
+// fee for a sell, and the amount received for a dividend. Sells also carry CostBasis, fifo or average, the Cost
+// of the units sold under that method and the RealizedGain of Amount over Cost.
+type Trade struct {
+	ID            int       `json:"id"`
+	UserID        int       `json:"-"`
+	AccountID     int       `json:"accountId"`
+	Security      Security  `json:"security"`
+	Kind          string    `json:"kind"`
+	Quantity      Quantity  `json:"quantity"`
+	Price         Money     `json:"price"`
+	Fee           Money     `json:"fee"`
+	Amount        Money     `json:"amount"`
+	CostBasis     string    `json:"costBasis,omitempty"`
+	Cost          Money     `json:"cost"`
+	RealizedGain  Money     `json:"realizedGain"`
+	TransactionID int       `json:"transactionId"`
+	Date          time.Time `json:"date"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Lot is what is left of the units bought by one trade. Cost is the cost basis of the Remaining units.
+type Lot struct {
+	TradeID   int       `json:"tradeId"`
+	Date      time.Time `json:"date"`
+	Quantity  Quantity  `json:"quantity"`
+	Remaining Quantity  `json:"remaining"`
+	Cost      Money     `json:"cost"`
+}
+
+// Holding is the position of an account in one security. Price is the latest known on the portfolio date, either
+// posted for the security or paid in one of its trades, and dates from PriceDate.
+type Holding struct {
+	Security       Security   `json:"security"`
+	Quantity       Quantity   `json:"quantity"`
+	Cost           Money      `json:"cost"`
+	Price          Money      `json:"price"`
+	PriceDate      *time.Time `json:"priceDate,omitempty"`
+	MarketValue    Money      `json:"marketValue"`
+	UnrealizedGain Money      `json:"unrealizedGain"`
+	RealizedGain   Money      `json:"realizedGain"`
+	Dividends      Money      `json:"dividends"`
+	Lots           []*Lot     `json:"lots"`
+}
+
+// Portfolio sums the holdings of an investment account on Date, in the account currency.
+type Portfolio struct {
+	AccountID      int        `json:"accountId"`
+	Currency       string     `json:"currency"`
+	Date           time.Time  `json:"date"`
+	Cost           Money      `json:"cost"`
+	MarketValue    Money      `json:"marketValue"`
+	UnrealizedGain Money      `json:"unrealizedGain"`
+	RealizedGain   Money      `json:"realizedGain"`
+	Dividends      Money      `json:"dividends"`
+	Holdings       []*Holding `json:"holdings"`
+}
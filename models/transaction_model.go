@@ -2,30 +2,64 @@ package models
 
 import "time"
 
+// Transaction is money moving in or out of an account.
 type Transaction struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name" validate:"required"`
-	Type        string    `json:"type" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	AmountIn    float64   `json:"amountIn" validate:"required"`
-	AmountOut   float64   `json:"amountOut" validate:"required"`
-	Status      string    `json:"status"`
-	Account     Account   `json:"account"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID               int                 `json:"id"`
+	UserID           int                 `json:"-"`
+	Name             string              `json:"name" validate:"required"`
+	Type             string              `json:"type" validate:"required"`
+	Description      string              `json:"description" validate:"required"`
+	AmountIn         Money               `json:"amountIn" validate:"required"`
+	AmountOut        Money               `json:"amountOut" validate:"required"`
+	RunningBalance   Money               `json:"runningBalance"`
+	Status           string              `json:"status"`
+	DeletedAt        *time.Time          `json:"deletedAt,omitempty"` // set while in the trash
+	TransferID       int                 `json:"transferId,omitempty"`
+	TradeID          int                 `json:"tradeId,omitempty"` // changes only through the trade
+	FitID            string              `json:"fitId,omitempty"`
+	Cleared          string              `json:"cleared"` // uncleared, cleared or reconciled; reconciled is read-only
+	ReconciliationID int                 `json:"reconciliationId,omitempty"`
+	ReversalOf       int                 `json:"reversalOf,omitempty"` // the transaction this entry cancels
+	ReversedBy       int                 `json:"reversedBy,omitempty"` // the entry cancelling this one
+	Voided           bool                `json:"voided"`               // set on both sides of a void
+	VoidedBy         int                 `json:"voidedBy,omitempty"`   // the reversal posted for a voided original
+	VoidReason       string              `json:"voidReason,omitempty"`
+	Account          Account             `json:"account"`
+	Category         *Category           `json:"category,omitempty"`
+	Splits           []*TransactionSplit `json:"splits,omitempty"`
+	Date             time.Time           `json:"date"`
+	CreatedAt        time.Time           `json:"createdAt"`
+	UpdatedAt        time.Time           `json:"updatedAt"`
+}
+
+// VoidResult pairs a voided transaction with the reversal posted for it.
+type VoidResult struct {
+	Original *Transaction `json:"original"`
+	Reversal *Transaction `json:"reversal"`
+}
+
+// TransactionSplit is a line item carrying part of the amount of a transaction, in the transaction's direction.
+// When a transaction has splits, category reports count the splits instead of the transaction's own category.
+type TransactionSplit struct {
+	ID       int       `json:"id"`
+	Amount   Money     `json:"amount"`
+	Category *Category `json:"category,omitempty"`
+	Memo     string    `json:"memo"`
 }
 
 type SummaryDaily struct {
-	Day        int     `json:"day"`
-	Month      int     `json:"month"`
-	Year       int     `json:"year"`
-	AverageIn  float64 `json:"averageIn"`
-	AverageOut float64 `json:"averageOut"`
+	Day        int    `json:"day"`
+	Month      int    `json:"month"`
+	Year       int    `json:"year"`
+	AverageIn  Money  `json:"averageIn"`
+	AverageOut Money  `json:"averageOut"`
+	Currency   string `json:"currency,omitempty"`
 }
 
 type SummaryMonthly struct {
-	Month      int     `json:"month"`
-	AverageIn  float64 `json:"averageIn"`
-	AverageOut float64 `json:"averageOut"`
-	Year       int     `json:"year"`
+	Month      int    `json:"month"`
+	AverageIn  Money  `json:"averageIn"`
+	AverageOut Money  `json:"averageOut"`
+	Year       int    `json:"year"`
+	Currency   string `json:"currency,omitempty"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type Transfer struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"-"`
+	FromAccount Account   `json:"fromAccount"`
+	ToAccount   Account   `json:"toAccount"`
+	Amount      Money     `json:"amount"`
+	Description string    `json:"description"`
+	OutTrxID    int       `json:"outTransactionId"`
+	InTrxID     int       `json:"inTransactionId"`
+	Date        time.Time `json:"date"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Trash lists the deleted accounts and transactions of a user that can still be restored. Transactions deleted
+// together with their account are restored with it and only appear under the account.
+type Trash struct {
+	Accounts     []*Account     `json:"accounts"`
+	Transactions []*Transaction `json:"transactions"`
+}
@@ -2,11 +2,14 @@ package models
 
 import "time"
 
+// User owns accounts and transactions. Role is "admin" for users allowed to reopen closed periods and is never
+// taken from a request body.
 type User struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email" validate:"required"`
 	Password  string    `json:"password" validate:"required"`
 	Name      string    `json:"name" validate:"required"`
+	Role      string    `json:"-"`
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
@@ -28,6 +28,7 @@ func NewAccountHandler(e *echo.Echo, ac account.Usecase, middleware *middleware.
 	e.POST("/v1/account", handler.Create, middleware.Authorize)
 	e.PATCH("/v1/account/:id", handler.Update, middleware.Authorize)
 	e.DELETE("/v1/account/:id", handler.Delete, middleware.Authorize)
+	e.POST("/v1/account/:id/restore", handler.Restore, middleware.Authorize)
 }
 
 // ShowAccount godoc
@@ -35,6 +36,7 @@ func NewAccountHandler(e *echo.Echo, ac account.Usecase, middleware *middleware.
 // @Description get list account
 // @Param keyword query string false "name search by keyword"
 // @Param type query string false "filter by type"
+// @Param currency query string false "filter by currency"
 // @Param limit query int true "limit list"
 // @Param offset query int true "offset list"
 // @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
@@ -45,6 +47,7 @@ func NewAccountHandler(e *echo.Echo, ac account.Usecase, middleware *middleware.
 // @Router /account [get]
 func (a *AccountHandler) FetchAll(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -81,7 +84,7 @@ func (a *AccountHandler) FetchAll(c echo.Context) error {
 		})
 	}
 
-	res, total, err := a.AccountUsecase.FetchAll(ctx, filter, keyword, limit, offset)
+	res, total, err := a.AccountUsecase.FetchAll(ctx, userId, filter, keyword, limit, offset)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -108,13 +111,19 @@ func (a *AccountHandler) FetchAll(c echo.Context) error {
 // @Router /account/{id} [get]
 func (a *AccountHandler) FetchById(c echo.Context) error {
 	idAcc, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	user, err := a.AccountUsecase.FetchById(ctx, idAcc)
+	user, err := a.AccountUsecase.FetchById(ctx, userId, idAcc)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -154,6 +163,8 @@ func (a *AccountHandler) Create(c echo.Context) error {
 		ctx = context.Background()
 	}
 
+	account.UserID = c.Get("userId").(int)
+
 	err = a.AccountUsecase.Create(ctx, &account)
 
 	if err != nil {
@@ -187,7 +198,7 @@ func (a *AccountHandler) Delete(c echo.Context) error {
 		ctx = context.Background()
 	}
 
-	err = a.AccountUsecase.Delete(ctx, idAcc)
+	err = a.AccountUsecase.Delete(ctx, c.Get("userId").(int), idAcc)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -198,6 +209,39 @@ func (a *AccountHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreAccount godoc
+// @Summary Restore account
+// @Description Restore a deleted account from the trash together with the transactions deleted with it
+// @Accept  json
+// @Produce  json
+// @Param id path int true "account id"
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Success 200 {object} models.Account
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/restore [post]
+func (a *AccountHandler) Restore(c echo.Context) error {
+	idAcc, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := a.AccountUsecase.Restore(ctx, c.Get("userId").(int), idAcc)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
 // UpdateAccount godoc
 // @Summary Update account
 // @Description Update account by ID
@@ -224,6 +268,7 @@ func (a *AccountHandler) Update(c echo.Context) error {
 	}
 
 	account.ID = idAcc
+	account.UserID = c.Get("userId").(int)
 
 	ctx := c.Request().Context()
 
@@ -263,6 +308,12 @@ func getStatusCode(err error) int {
 		return http.StatusNotFound
 	case helpers.ErrConflict:
 		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrReconciled:
+		return http.StatusConflict
+	case helpers.ErrPeriodClosed:
+		return http.StatusLocked
 	default:
 		return http.StatusInternalServerError
 	}
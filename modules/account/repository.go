@@ -2,14 +2,24 @@ package account
 
 import (
 	"context"
+	"time"
 
 	"github.com/arham09/fin-api/models"
 )
 
 type Repository interface {
-	FetchAll(ctx context.Context, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Account, total int, err error)
-	FetchById(ctx context.Context, id int) (res *models.Account, err error)
+	FetchAll(ctx context.Context, userId int, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Account, total int, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Account, err error)
 	Store(ctx context.Context, a *models.Account) error
 	Update(ctx context.Context, a *models.Account) error
-	Delete(ctx context.Context, id int) error
+	// Delete moves the account and its transactions to the trash, with the other legs of its transfers. It fails
+	// with ErrReconciled when one of them is reconciled and with ErrPeriodClosed when one falls in a closed period.
+	Delete(ctx context.Context, userId int, id int) error
+	FetchDeleted(ctx context.Context, userId int) ([]*models.Account, error)
+	// Restore brings the account back with the transactions deleted together with it, failing with ErrPeriodClosed
+	// when one of them falls in a closed period.
+	Restore(ctx context.Context, userId int, id int) error
+	// Purge hard deletes the accounts that went to the trash before the given time and have no transactions left,
+	// returning how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
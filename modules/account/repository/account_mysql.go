@@ -4,13 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/arham09/fin-api/helpers"
 	"github.com/arham09/fin-api/models"
 	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	pdr "github.com/arham09/fin-api/modules/period/repository"
 	"github.com/sirupsen/logrus"
 )
 
+// selectAccount lists the columns scanned by fetch.
+const selectAccount = `SELECT id, user_id, name, type, description, currency, balance, status, deleted_at, created_at, updated_at FROM accounts`
+
+// queryer is the connection, or a database transaction when a read must see the writes made in it.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 type mySqlAccountRepository struct {
 	Conn *sql.DB
 }
@@ -19,8 +31,8 @@ func NewMysqlAccountRepository(Conn *sql.DB) account.Repository {
 	return &mySqlAccountRepository{Conn}
 }
 
-func (m *mySqlAccountRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Account, error) {
-	rows, err := m.Conn.QueryContext(ctx, query, args...)
+func (m *mySqlAccountRepository) fetch(ctx context.Context, q queryer, query string, args ...interface{}) ([]*models.Account, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		logrus.Error(err)
@@ -39,13 +51,18 @@ func (m *mySqlAccountRepository) fetch(ctx context.Context, query string, args .
 	for rows.Next() {
 		t := new(models.Account)
 		status := int(0)
+		deletedAt := sql.NullTime{}
 
 		err = rows.Scan(
 			&t.ID,
+			&t.UserID,
 			&t.Name,
 			&t.Type,
 			&t.Description,
+			&t.Currency,
+			&t.Balance,
 			&status,
+			&deletedAt,
 			&t.CreatedAt,
 			&t.UpdatedAt,
 		)
@@ -61,14 +78,18 @@ func (m *mySqlAccountRepository) fetch(ctx context.Context, query string, args .
 			t.Status = "inactive"
 		}
 
+		if deletedAt.Valid {
+			t.DeletedAt = &deletedAt.Time
+		}
+
 		result = append(result, t)
 	}
 
 	return result, nil
 }
 
-func (m *mySqlAccountRepository) fetchTotal(ctx context.Context, query string) (int, error) {
-	rows, err := m.Conn.QueryContext(ctx, query)
+func (m *mySqlAccountRepository) fetchTotal(ctx context.Context, query string, args ...interface{}) (int, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		logrus.Error(err)
@@ -102,33 +123,48 @@ func (m *mySqlAccountRepository) fetchTotal(ctx context.Context, query string) (
 	return result[0], nil
 }
 
-func (m *mySqlAccountRepository) FetchAll(ctx context.Context, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Account, total int, err error) {
-	query := `SELECT id, name, type, description, status, created_at, updated_at FROM accounts WHERE status=1`
-	countQuery := `SELECT COUNT(id) AS total FROM accounts WHERE status=1`
+// filterColumns maps the filters accepted by FetchAll to the column they compare.
+var filterColumns = map[string]string{
+	"type":     "type",
+	"currency": "currency",
+}
+
+func (m *mySqlAccountRepository) FetchAll(ctx context.Context, userId int, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Account, total int, err error) {
+	query := selectAccount + ` WHERE status=1 AND user_id=?`
+	countQuery := `SELECT COUNT(id) AS total FROM accounts WHERE status=1 AND user_id=?`
+	args := []interface{}{userId}
 
 	for filter, param := range filters {
-		addFilter := fmt.Sprintf(" AND %v = \"%v\"", filter, param)
+		column, ok := filterColumns[filter]
+
+		if !ok {
+			return nil, 0, helpers.ErrBadParamInput
+		}
+
+		addFilter := fmt.Sprintf(" AND %s = ?", column)
 
 		query = query + addFilter
 		countQuery = countQuery + addFilter
+		args = append(args, param)
 	}
 
 	if keyword != "" {
-		addSearch := " AND name LIKE " + "'%" + keyword + "%'"
+		addSearch := " AND name LIKE ?"
 		query = query + addSearch
 		countQuery = countQuery + addSearch
+		args = append(args, "%"+keyword+"%")
 	}
 
-	totalData, err := m.fetchTotal(ctx, countQuery)
+	totalData, err := m.fetchTotal(ctx, countQuery, args...)
 
 	if err != nil {
 		return nil, 0, err
 	}
 
-	pagination := fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	pagination := fmt.Sprintf(" ORDER BY id LIMIT %d OFFSET %d", limit, offset)
 	query = query + pagination
 
-	list, err := m.fetch(ctx, query)
+	list, err := m.fetch(ctx, m.Conn, query, args...)
 
 	if err != nil {
 		return nil, 0, err
@@ -137,10 +173,10 @@ func (m *mySqlAccountRepository) FetchAll(ctx context.Context, filters map[strin
 	return list, totalData, nil
 }
 
-func (m *mySqlAccountRepository) FetchById(ctx context.Context, id int) (res *models.Account, err error) {
-	query := `SELECT id, name, type, description, status, created_at, updated_at FROM accounts WHERE status=1 AND id = ?`
+func (m *mySqlAccountRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Account, err error) {
+	query := selectAccount + ` WHERE status=1 AND user_id = ? AND id = ?`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, m.Conn, query, userId, id)
 
 	if err != nil {
 		return nil, err
@@ -156,70 +192,312 @@ func (m *mySqlAccountRepository) FetchById(ctx context.Context, id int) (res *mo
 }
 
 func (m *mySqlAccountRepository) Store(ctx context.Context, a *models.Account) error {
-	query := `INSERT accounts SET name=?, type=?, description=?, created_at=?, updated_at=?`
+	query := `INSERT accounts SET user_id=?, name=?, type=?, description=?, currency=?, created_at=?, updated_at=?`
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, a.UserID, a.Name, a.Type, a.Description, a.Currency, a.CreatedAt, a.UpdatedAt)
 
-	if err != nil {
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		a.ID = int(lastID)
+
+		return adr.Write(ctx, tx, a.UserID, a.UserID, audit.EntityAccount, a.ID, "create", nil, a)
+	})
+}
+
+func (m *mySqlAccountRepository) Update(ctx context.Context, a *models.Account) error {
+	query := `UPDATE accounts SET name=?, type=?, description=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := m.snapshot(ctx, tx, a.UserID, a.ID)
+
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, a.Name, a.Type, a.Description, a.UpdatedAt, a.UserID, a.ID)
+		if err != nil {
+			return err
+		}
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affect != 1 {
+			err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+			return err
+		}
+
+		after, err := m.snapshot(ctx, tx, a.UserID, a.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, a.UserID, a.UserID, audit.EntityAccount, a.ID, "update", before, after)
+	})
+}
+
+// checkHistory refuses to move the transactions matching where in or out of the trash when one of them falls in a
+// closed period of the user or, when reconciled is set, belongs to a finalized reconciliation.
+func (m *mySqlAccountRepository) checkHistory(ctx context.Context, tx *sql.Tx, userId int, reconciled bool, where string, args ...interface{}) error {
+	count, locked := int(0), int(0)
+	first := sql.NullTime{}
+	query := `SELECT COUNT(id), COALESCE(SUM(cleared=2), 0), MIN(trx_date) FROM transactions WHERE ` + where
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&count, &locked, &first); err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, a.Name, a.Type, a.Description, a.CreatedAt, a.UpdatedAt)
+	if reconciled && locked > 0 {
+		return helpers.ErrReconciled
+	}
 
-	if err != nil {
-		return err
+	if !first.Valid {
+		return nil
 	}
 
-	lastID, err := res.LastInsertId()
+	return pdr.CheckOpen(ctx, tx, userId, first.Time)
+}
+
+// snapshot reads the account of the user, active or not, as seen from tx.
+func (m *mySqlAccountRepository) snapshot(ctx context.Context, tx *sql.Tx, userId int, id int) (*models.Account, error) {
+	list, err := m.fetch(ctx, tx, selectAccount+` WHERE user_id = ? AND id = ?`, userId, id)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	a.ID = int(lastID)
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
 
-	return nil
+	return list[0], nil
 }
 
-func (m *mySqlAccountRepository) Update(ctx context.Context, a *models.Account) error {
-	query := `UPDATE accounts SET name=?, type=?, description=?, updated_at=? WHERE status=1 AND id = ?`
+// logCascade records the transactions matching where as moved in or out of the trash together with the account.
+func (m *mySqlAccountRepository) logCascade(ctx context.Context, tx *sql.Tx, userId int, action string, where string, args ...interface{}) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM transactions WHERE `+where, args...)
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, a.Name, a.Type, a.Description, a.UpdatedAt, a.ID)
-	if err != nil {
-		return err
+	ids := make([]int, 0)
+
+	for rows.Next() {
+		id := int(0)
+
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+
+		ids = append(ids, id)
 	}
-	affect, err := res.RowsAffected()
-	if err != nil {
+
+	if err = rows.Close(); err != nil {
 		return err
 	}
-	if affect != 1 {
-		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
 
-		return err
+	for _, id := range ids {
+		if err = adr.Write(ctx, tx, userId, userId, audit.EntityTransaction, id, action, nil, nil); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (m *mySqlAccountRepository) Delete(ctx context.Context, id int) error {
-	query := `UPDATE accounts SET status=0 WHERE id = ?`
+// Delete moves the account to the trash together with its transactions. Transfers to and from other accounts go
+// as a whole, so their legs in the other accounts are taken out of those balances.
+func (m *mySqlAccountRepository) Delete(ctx context.Context, userId int, id int) error {
+	now := time.Now()
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := m.snapshot(ctx, tx, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `UPDATE accounts SET status=0, deleted_at=? WHERE status=1 AND user_id = ? AND id = ?`, now, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if affect, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affect != 1 {
+			return helpers.ErrNotFound
+		}
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+		where := `status=1 AND user_id = ?
+			AND (account_id = ? OR transfer_id IN (SELECT id FROM transfers WHERE status=1 AND user_id = ? AND (from_account_id = ? OR to_account_id = ?)))`
+
+		// The cascade would take reconciled or closed history out of the books, so the account has to stay.
+		err = m.checkHistory(ctx, tx, userId, true, where+` FOR UPDATE`, userId, id, userId, id, id)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET status=0, deleted_at=?, deleted_with_account=? WHERE `+where, now, id, userId, id, userId, id, id)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transfers SET status=0 WHERE status=1 AND user_id = ? AND (from_account_id = ? OR to_account_id = ?)`, userId, id, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.applyCascade(ctx, tx, userId, id, -1); err != nil {
+			return err
+		}
+
+		err = m.logCascade(ctx, tx, userId, "delete_with_account", `status=0 AND user_id = ? AND deleted_with_account = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, userId, userId, audit.EntityAccount, id, "delete", before, nil)
+	})
+}
+
+func (m *mySqlAccountRepository) FetchDeleted(ctx context.Context, userId int) ([]*models.Account, error) {
+	query := selectAccount + ` WHERE status=0 AND deleted_at IS NOT NULL AND user_id = ? ORDER BY deleted_at DESC, id`
+
+	return m.fetch(ctx, m.Conn, query, userId)
+}
+
+// Restore brings the account back from the trash with the transactions deleted together with it. It fails with
+// ErrConflict when one of those transactions is the leg of a transfer into an account that is no longer active.
+func (m *mySqlAccountRepository) Restore(ctx context.Context, userId int, id int) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE accounts SET status=1, deleted_at=NULL WHERE status=0 AND deleted_at IS NOT NULL AND user_id = ? AND id = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if affect, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affect != 1 {
+			return helpers.ErrNotFound
+		}
+
+		inactive := int(0)
+		query := `SELECT COUNT(t.id) FROM transactions t JOIN accounts a ON t.account_id=a.id WHERE t.status=0 AND t.user_id = ? AND t.deleted_with_account = ? AND a.status <> 1`
+
+		if err = tx.QueryRowContext(ctx, query, userId, id).Scan(&inactive); err != nil {
+			return err
+		}
+
+		if inactive > 0 {
+			return helpers.ErrConflict
+		}
+
+		err = m.checkHistory(ctx, tx, userId, false, `status=0 AND user_id = ? AND deleted_with_account = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		err = m.applyCascade(ctx, tx, userId, id, 1)
+
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE transfers SET status=1 WHERE user_id = ? AND id IN (SELECT transfer_id FROM transactions WHERE status=0 AND user_id = ? AND deleted_with_account = ?)`
+
+		_, err = tx.ExecContext(ctx, query, userId, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		err = m.logCascade(ctx, tx, userId, "restore_with_account", `status=0 AND user_id = ? AND deleted_with_account = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET status=1, deleted_at=NULL, deleted_with_account=NULL WHERE status=0 AND user_id = ? AND deleted_with_account = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		after, err := m.snapshot(ctx, tx, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, userId, userId, audit.EntityAccount, id, "restore", nil, after)
+	})
+}
+
+// applyCascade adds sign times the transactions deleted together with the account to the balances of the other
+// accounts they belong to. The account's own balance is left as it was when it was deleted.
+func (m *mySqlAccountRepository) applyCascade(ctx context.Context, tx *sql.Tx, userId int, id int, sign int) error {
+	query := `UPDATE accounts a JOIN (SELECT account_id, SUM(amount_in - amount_out) AS net FROM transactions
+		WHERE status=0 AND user_id = ? AND deleted_with_account = ? AND account_id <> ? GROUP BY account_id) t ON a.id=t.account_id
+		SET a.balance = a.balance + ? * t.net`
+
+	_, err := tx.ExecContext(ctx, query, userId, id, id, sign)
+
+	return err
+}
+
+func (m *mySqlAccountRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM accounts WHERE status=0 AND deleted_at < ? AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.account_id=accounts.id)`
+
+	res, err := m.Conn.ExecContext(ctx, query, before)
+
+	if err != nil {
+		return 0, err
+	}
+
+	affect, err := res.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affect), nil
+}
+
+func (m *mySqlAccountRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
 
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.ExecContext(ctx, id)
+	err = fn(tx)
 
 	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
@@ -8,9 +8,11 @@ import (
 
 type Usecase interface {
 	// FetchAll(ctx context.Context, search string, limit int, offset int) (res *models.Account, err error)
-	FetchAll(c context.Context, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Account, int, error)
-	FetchById(ctx context.Context, id int) (res *models.Account, err error)
+	FetchAll(c context.Context, userId int, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Account, int, error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Account, err error)
 	Create(ctx context.Context, a *models.Account) error
 	Update(ctx context.Context, a *models.Account) (*models.Account, error)
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, userId int, id int) error
+	// Restore brings an account back from the trash together with the transactions deleted with it.
+	Restore(ctx context.Context, userId int, id int) (*models.Account, error)
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/arham09/fin-api/helpers"
@@ -21,12 +22,12 @@ func NewAccountUsecase(a account.Repository, timeout time.Duration) account.Usec
 	}
 }
 
-func (a *accountUsecase) FetchAll(c context.Context, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Account, int, error) {
+func (a *accountUsecase) FetchAll(c context.Context, userId int, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Account, int, error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 
 	defer cancel()
 
-	res, total, err := a.accountRepo.FetchAll(ctx, filter, keyword, limit, offset)
+	res, total, err := a.accountRepo.FetchAll(ctx, userId, filter, keyword, limit, offset)
 
 	if err != nil {
 		return nil, 0, err
@@ -35,12 +36,12 @@ func (a *accountUsecase) FetchAll(c context.Context, filter map[string]interface
 	return res, total, nil
 }
 
-func (a *accountUsecase) FetchById(c context.Context, id int) (*models.Account, error) {
+func (a *accountUsecase) FetchById(c context.Context, userId int, id int) (*models.Account, error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 
 	defer cancel()
 
-	res, err := a.accountRepo.FetchById(ctx, id)
+	res, err := a.accountRepo.FetchById(ctx, userId, id)
 
 	if err != nil {
 		return nil, err
@@ -49,11 +50,11 @@ func (a *accountUsecase) FetchById(c context.Context, id int) (*models.Account,
 	return res, nil
 }
 
-func (a *accountUsecase) Delete(c context.Context, id int) error {
+func (a *accountUsecase) Delete(c context.Context, userId int, id int) error {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
 
-	existEmail, err := a.accountRepo.FetchById(ctx, id)
+	existEmail, err := a.accountRepo.FetchById(ctx, userId, id)
 
 	if err != nil {
 		return err
@@ -63,7 +64,20 @@ func (a *accountUsecase) Delete(c context.Context, id int) error {
 		return helpers.ErrNotFound
 	}
 
-	return a.accountRepo.Delete(ctx, id)
+	return a.accountRepo.Delete(ctx, userId, id)
+}
+
+func (a *accountUsecase) Restore(c context.Context, userId int, id int) (*models.Account, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	err := a.accountRepo.Restore(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a.accountRepo.FetchById(ctx, userId, id)
 }
 
 func (a *accountUsecase) Create(c context.Context, account *models.Account) error {
@@ -71,15 +85,21 @@ func (a *accountUsecase) Create(c context.Context, account *models.Account) erro
 
 	defer cancel()
 
-	account.CreatedAt = time.Now()
-	account.UpdatedAt = time.Now()
+	if account.Currency == "" {
+		account.Currency = helpers.DefaultCurrency
+	}
 
-	err := a.accountRepo.Store(ctx, account)
+	account.Currency = strings.ToUpper(account.Currency)
 
-	if err != nil {
+	if err := helpers.VerifyCurrency(account.Currency); err != nil {
 		return err
 	}
-	return nil
+
+	account.Balance = 0
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = time.Now()
+
+	return a.accountRepo.Store(ctx, account)
 }
 
 func (a *accountUsecase) Update(c context.Context, account *models.Account) (*models.Account, error) {
@@ -87,7 +107,7 @@ func (a *accountUsecase) Update(c context.Context, account *models.Account) (*mo
 
 	defer cancel()
 
-	existID, err := a.accountRepo.FetchById(ctx, account.ID)
+	existID, err := a.accountRepo.FetchById(ctx, account.UserID, account.ID)
 
 	if err != nil {
 		return nil, err
@@ -97,6 +117,11 @@ func (a *accountUsecase) Update(c context.Context, account *models.Account) (*mo
 		return nil, helpers.ErrNotFound
 	}
 
+	// Transactions inherit the currency of their account, so it cannot change once the account exists.
+	if account.Currency != "" && !strings.EqualFold(account.Currency, existID.Currency) {
+		return nil, helpers.ErrBadParamInput
+	}
+
 	account.UpdatedAt = time.Now()
 
 	err = a.accountRepo.Update(c, account)
@@ -105,11 +130,5 @@ func (a *accountUsecase) Update(c context.Context, account *models.Account) (*mo
 		return nil, err
 	}
 
-	res, err := a.accountRepo.FetchById(c, account.ID)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return a.accountRepo.FetchById(c, account.UserID, account.ID)
 }
@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/attachment"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentHandler struct {
+	AttachmentUsecase attachment.Usecase
+}
+
+func NewAttachmentHandler(e *echo.Echo, au attachment.Usecase, middleware *middleware.Middleware) {
+	handler := &AttachmentHandler{
+		AttachmentUsecase: au,
+	}
+
+	e.GET("/v1/transaction/:id/attachments", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/transaction/:id/attachments/:attachmentId", handler.Download, middleware.Authorize)
+	e.POST("/v1/transaction/:id/attachments", handler.Create, middleware.Authorize)
+	e.DELETE("/v1/transaction/:id/attachments/:attachmentId", handler.Delete, middleware.Authorize)
+}
+
+// ShowAttachment godoc
+// @Summary Show List Attachment
+// @Description get every attachment of a transaction
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Attachment
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/attachments [get]
+func (h *AttachmentHandler) FetchAll(c echo.Context) error {
+	trxId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.AttachmentUsecase.FetchAll(ctx, c.Get("userId").(int), trxId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an Attachment
+// @Description download the file of an attachment
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Param attachmentId path int true "Attachment id"
+// @Produce  octet-stream
+// @Success 200 {file} file
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) Download(c echo.Context) error {
+	trxId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	id, err := strconv.Atoi(c.Param("attachmentId"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, body, err := h.AttachmentUsecase.Open(ctx, c.Get("userId").(int), trxId, id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer body.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", res.FileName))
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(res.Size, 10))
+
+	return c.Stream(http.StatusOK, res.ContentType, body)
+}
+
+// CreateAttachment godoc
+// @Summary Upload an Attachment
+// @Description Attach a JPEG, PNG, GIF or PDF file of at most 10 MB to a transaction
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Param file formData file true "Attachment file"
+// @Success 201 {object} models.Attachment
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/attachments [post]
+func (h *AttachmentHandler) Create(c echo.Context) error {
+	trxId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	a := &models.Attachment{
+		UserID:        c.Get("userId").(int),
+		TransactionID: trxId,
+		FileName:      filepath.Base(file.Filename),
+	}
+
+	err = h.AttachmentUsecase.Create(ctx, a, src)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, a)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete Attachment
+// @Description Delete attachment by ID together with its file
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Param attachmentId path int true "Attachment id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) Delete(c echo.Context) error {
+	trxId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	id, err := strconv.Atoi(c.Param("attachmentId"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.AttachmentUsecase.Delete(ctx, c.Get("userId").(int), trxId, id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrFileTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case helpers.ErrUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
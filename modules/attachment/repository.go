@@ -0,0 +1,14 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int, trxId int) (res []*models.Attachment, err error)
+	FetchById(ctx context.Context, userId int, trxId int, id int) (res *models.Attachment, err error)
+	Store(ctx context.Context, a *models.Attachment) error
+	Delete(ctx context.Context, userId int, id int) error
+}
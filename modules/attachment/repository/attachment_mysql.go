@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/attachment"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlAttachmentRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlAttachmentRepository(Conn *sql.DB) attachment.Repository {
+	return &mySqlAttachmentRepository{Conn}
+}
+
+func (m *mySqlAttachmentRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Attachment, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Attachment, 0)
+
+	for rows.Next() {
+		a := new(models.Attachment)
+
+		err = rows.Scan(
+			&a.ID,
+			&a.UserID,
+			&a.TransactionID,
+			&a.FileName,
+			&a.ContentType,
+			&a.Size,
+			&a.StorageKey,
+			&a.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, a)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlAttachmentRepository) FetchAll(ctx context.Context, userId int, trxId int) (res []*models.Attachment, err error) {
+	query := `SELECT id, user_id, transaction_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE user_id = ? AND transaction_id = ? ORDER BY id`
+
+	return m.fetch(ctx, query, userId, trxId)
+}
+
+func (m *mySqlAttachmentRepository) FetchById(ctx context.Context, userId int, trxId int, id int) (res *models.Attachment, err error) {
+	query := `SELECT id, user_id, transaction_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE user_id = ? AND transaction_id = ? AND id = ?`
+
+	list, err := m.fetch(ctx, query, userId, trxId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlAttachmentRepository) Store(ctx context.Context, a *models.Attachment) error {
+	query := `INSERT attachments SET user_id=?, transaction_id=?, file_name=?, content_type=?, size=?, storage_key=?, created_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, a.UserID, a.TransactionID, a.FileName, a.ContentType, a.Size, a.StorageKey, a.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	a.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlAttachmentRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `DELETE FROM attachments WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
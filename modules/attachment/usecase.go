@@ -0,0 +1,19 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int, trxId int) ([]*models.Attachment, error)
+	// Open returns the attachment with its content, which the caller must close.
+	Open(c context.Context, userId int, trxId int, id int) (*models.Attachment, io.ReadCloser, error)
+	// Create stores the content read from r and fills in the content type and size of a.
+	Create(c context.Context, a *models.Attachment, r io.Reader) error
+	Delete(c context.Context, userId int, trxId int, id int) error
+	// DeleteAll removes every attachment of a transaction together with the stored files.
+	DeleteAll(c context.Context, userId int, trxId int) error
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/configs/storage"
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/attachment"
+	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxSize is the largest attachment accepted, in bytes.
+const MaxSize = 10 << 20
+
+// contentTypes lists the accepted types, detected from the content rather than taken from the upload.
+var contentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"application/pdf": true,
+}
+
+type attachmentUsecase struct {
+	attachmentRepo attachment.Repository
+	trxRepo        transaction.Repository
+	storage        storage.Storage
+	contextTimeout time.Duration
+}
+
+func NewAttachmentUsecase(a attachment.Repository, t transaction.Repository, s storage.Storage, timeout time.Duration) attachment.Usecase {
+	return &attachmentUsecase{
+		attachmentRepo: a,
+		trxRepo:        t,
+		storage:        s,
+		contextTimeout: timeout,
+	}
+}
+
+// limitedReader counts what is read through it and fails once more than max bytes were read.
+type limitedReader struct {
+	r    io.Reader
+	n    int64
+	max  int64
+	fail error
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+
+	if l.n > l.max {
+		return n, l.fail
+	}
+
+	return n, err
+}
+
+func (u *attachmentUsecase) FetchAll(c context.Context, userId int, trxId int) ([]*models.Attachment, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.trxRepo.FetchById(ctx, userId, trxId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachmentRepo.FetchAll(ctx, userId, trxId)
+}
+
+func (u *attachmentUsecase) Open(c context.Context, userId int, trxId int, id int) (*models.Attachment, io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.attachmentRepo.FetchById(ctx, userId, trxId, id)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The content is read after this call returns, so it is not bound to the usecase timeout.
+	body, err := u.storage.Get(c, res.StorageKey)
+
+	if err == storage.ErrNotExist {
+		return nil, nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, body, nil
+}
+
+func (u *attachmentUsecase) Create(c context.Context, a *models.Attachment, r io.Reader) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.trxRepo.FetchById(ctx, a.UserID, a.TransactionID)
+
+	if err != nil {
+		return err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return helpers.ErrBadParamInput
+		}
+
+		return err
+	}
+
+	head = head[:n]
+	a.ContentType = strings.TrimSpace(strings.Split(http.DetectContentType(head), ";")[0])
+
+	if !contentTypes[a.ContentType] {
+		return helpers.ErrUnsupportedMediaType
+	}
+
+	name := make([]byte, 16)
+
+	if _, err = rand.Read(name); err != nil {
+		return err
+	}
+
+	a.StorageKey = fmt.Sprintf("%d/%d/%s", a.UserID, a.TransactionID, hex.EncodeToString(name))
+
+	content := &limitedReader{r: io.MultiReader(bytes.NewReader(head), r), max: MaxSize, fail: helpers.ErrFileTooLarge}
+
+	err = u.storage.Put(ctx, a.StorageKey, a.ContentType, content)
+
+	if err != nil {
+		return err
+	}
+
+	a.Size = content.n
+	a.CreatedAt = time.Now()
+
+	err = u.attachmentRepo.Store(ctx, a)
+
+	if err != nil {
+		u.removeFile(ctx, a)
+		return err
+	}
+
+	return nil
+}
+
+func (u *attachmentUsecase) Delete(c context.Context, userId int, trxId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.attachmentRepo.FetchById(ctx, userId, trxId, id)
+
+	if err != nil {
+		return err
+	}
+
+	err = u.attachmentRepo.Delete(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	u.removeFile(ctx, res)
+
+	return nil
+}
+
+func (u *attachmentUsecase) DeleteAll(c context.Context, userId int, trxId int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	list, err := u.attachmentRepo.FetchAll(ctx, userId, trxId)
+
+	if err != nil {
+		return err
+	}
+
+	for _, a := range list {
+		err = u.attachmentRepo.Delete(ctx, userId, a.ID)
+
+		if err != nil {
+			return err
+		}
+
+		u.removeFile(ctx, a)
+	}
+
+	return nil
+}
+
+// removeFile deletes the stored content of a. The row is already gone, so a failure only leaves an orphaned
+// file behind and is logged rather than returned.
+func (u *attachmentUsecase) removeFile(ctx context.Context, a *models.Attachment) {
+	if err := u.storage.Delete(ctx, a.StorageKey); err != nil {
+		logrus.Errorf("attachment %d: removing %s: %v", a.ID, a.StorageKey, err)
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/modules/audit"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditHandler struct {
+	AuditUsecase audit.Usecase
+}
+
+func NewAuditHandler(e *echo.Echo, au audit.Usecase, middleware *middleware.Middleware) {
+	handler := &AuditHandler{
+		AuditUsecase: au,
+	}
+
+	e.GET("/v1/account/:id/history", handler.FetchAccountHistory, middleware.Authorize)
+	e.GET("/v1/transaction/:id/history", handler.FetchTransactionHistory, middleware.Authorize)
+}
+
+// ShowAccountHistory godoc
+// @Summary Show an Account History
+// @Description get every change made to an account, oldest first, with the account before and after each change. Deleted accounts keep their history
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Success 200 {array} models.AuditLog
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/history [get]
+func (h *AuditHandler) FetchAccountHistory(c echo.Context) error {
+	return h.fetchHistory(c, audit.EntityAccount)
+}
+
+// ShowTransactionHistory godoc
+// @Summary Show a Transaction History
+// @Description get every change made to a transaction, oldest first, with the transaction before and after each change. Deleted transactions keep their history
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Success 200 {array} models.AuditLog
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/history [get]
+func (h *AuditHandler) FetchTransactionHistory(c echo.Context) error {
+	return h.fetchHistory(c, audit.EntityTransaction)
+}
+
+func (h *AuditHandler) fetchHistory(c echo.Context, entity string) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.AuditUsecase.FetchHistory(ctx, c.Get("userId").(int), entity, id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
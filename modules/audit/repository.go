@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Repository reads the log. Rows are written by the repository of the changed record, inside its own database
+// transaction, through repository.Write.
+type Repository interface {
+	// FetchByEntity lists the changes to one record of the user, oldest first.
+	FetchByEntity(ctx context.Context, userId int, entity string, entityId int) ([]*models.AuditLog, error)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlAuditRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlAuditRepository(Conn *sql.DB) audit.Repository {
+	return &mySqlAuditRepository{Conn}
+}
+
+func nullableJSON(data []byte) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	return string(data)
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+// Write appends a change to the log through tx, so that it is stored if and only if the change itself commits.
+// before and after are stored as JSON and left empty when nil. The request id is taken from ctx.
+func Write(ctx context.Context, tx *sql.Tx, userId int, actorId int, entity string, entityId int, action string, before interface{}, after interface{}) error {
+	query := `INSERT audit_logs SET user_id=?, actor_id=?, entity=?, entity_id=?, action=?, before_data=?, after_data=?, request_id=?, created_at=?`
+
+	b, err := snapshot(before)
+
+	if err != nil {
+		return err
+	}
+
+	a, err := snapshot(after)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, userId, actorId, entity, entityId, action, nullableJSON(b), nullableJSON(a), helpers.RequestID(ctx), time.Now())
+
+	return err
+}
+
+func (m *mySqlAuditRepository) FetchByEntity(ctx context.Context, userId int, entity string, entityId int) ([]*models.AuditLog, error) {
+	query := `SELECT id, user_id, actor_id, entity, entity_id, action, before_data, after_data, request_id, created_at
+		FROM audit_logs WHERE user_id = ? AND entity = ? AND entity_id = ? ORDER BY id`
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId, entity, entityId)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.AuditLog, 0)
+
+	for rows.Next() {
+		l := new(models.AuditLog)
+		before := sql.NullString{}
+		after := sql.NullString{}
+
+		err = rows.Scan(
+			&l.ID,
+			&l.UserID,
+			&l.ActorID,
+			&l.Entity,
+			&l.EntityID,
+			&l.Action,
+			&before,
+			&after,
+			&l.RequestID,
+			&l.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if before.Valid {
+			l.Before = []byte(before.String)
+		}
+
+		if after.Valid {
+			l.After = []byte(after.String)
+		}
+
+		result = append(result, l)
+	}
+
+	return result, nil
+}
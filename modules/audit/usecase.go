@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Entities whose changes are recorded.
+const (
+	EntityAccount     = "account"
+	EntityTransaction = "transaction"
+	EntityPeriodClose = "period_close"
+)
+
+type Usecase interface {
+	FetchHistory(c context.Context, userId int, entity string, entityId int) ([]*models.AuditLog, error)
+}
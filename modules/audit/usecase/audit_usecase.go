@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+)
+
+type auditUsecase struct {
+	auditRepo      audit.Repository
+	contextTimeout time.Duration
+}
+
+func NewAuditUsecase(a audit.Repository, timeout time.Duration) audit.Usecase {
+	return &auditUsecase{
+		auditRepo:      a,
+		contextTimeout: timeout,
+	}
+}
+
+func (a *auditUsecase) FetchHistory(c context.Context, userId int, entity string, entityId int) ([]*models.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+
+	defer cancel()
+
+	return a.auditRepo.FetchByEntity(ctx, userId, entity, entityId)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/modules/balance"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type BalanceHandler struct {
+	BalanceUsecase balance.Usecase
+}
+
+func NewBalanceHandler(e *echo.Echo, bu balance.Usecase, middleware *middleware.Middleware) {
+	handler := &BalanceHandler{
+		BalanceUsecase: bu,
+	}
+
+	e.GET("/v1/account/:id/balance", handler.FetchBalance, middleware.Authorize)
+}
+
+// ShowBalance godoc
+// @Summary Show an account balance
+// @Description get the current balance of an account, or its balance at the end of a given date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "account id"
+// @Param date query string false "balance as of date (YYYY-MM-DD)"
+// @Success 200 {object} models.Balance
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/balance [get]
+func (b *BalanceHandler) FetchBalance(c echo.Context) error {
+	idAcc, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var asOf *time.Time
+
+	if date := c.QueryParam("date"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+
+		asOf = &parsed
+	}
+
+	res, err := b.BalanceUsecase.FetchBalance(ctx, userId, idAcc, asOf)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,14 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchCurrent(ctx context.Context, userId int, accountId int) (res *models.Balance, err error)
+	// FetchAsOf sums the transactions dated on or before date.
+	FetchAsOf(ctx context.Context, userId int, accountId int, date time.Time) (res *models.Balance, err error)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/balance"
+)
+
+type mySqlBalanceRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlBalanceRepository(Conn *sql.DB) balance.Repository {
+	return &mySqlBalanceRepository{Conn}
+}
+
+func (m *mySqlBalanceRepository) FetchCurrent(ctx context.Context, userId int, accountId int) (res *models.Balance, err error) {
+	query := `SELECT id, balance FROM accounts WHERE status=1 AND user_id = ? AND id = ?`
+
+	res = &models.Balance{AsOf: time.Now()}
+
+	err = m.Conn.QueryRowContext(ctx, query, userId, accountId).Scan(&res.AccountID, &res.Balance)
+
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (m *mySqlBalanceRepository) FetchAsOf(ctx context.Context, userId int, accountId int, date time.Time) (res *models.Balance, err error) {
+	query := `SELECT a.id, COALESCE(SUM(t.amount_in - t.amount_out), 0) FROM accounts a LEFT JOIN transactions t ON t.account_id=a.id AND t.status=1 AND t.trx_date <= ? WHERE a.status=1 AND a.user_id = ? AND a.id = ? GROUP BY a.id`
+
+	res = &models.Balance{AsOf: date}
+
+	err = m.Conn.QueryRowContext(ctx, query, date.Format("2006-01-02"), userId, accountId).Scan(&res.AccountID, &res.Balance)
+
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
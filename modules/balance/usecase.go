@@ -0,0 +1,13 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// FetchBalance returns the current balance of the account, or the balance at the end of asOf when it is set.
+	FetchBalance(c context.Context, userId int, accountId int, asOf *time.Time) (*models.Balance, error)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/balance"
+)
+
+type balanceUsecase struct {
+	balanceRepo    balance.Repository
+	contextTimeout time.Duration
+}
+
+func NewBalanceUsecase(b balance.Repository, timeout time.Duration) balance.Usecase {
+	return &balanceUsecase{
+		balanceRepo:    b,
+		contextTimeout: timeout,
+	}
+}
+
+func (b *balanceUsecase) FetchBalance(c context.Context, userId int, accountId int, asOf *time.Time) (*models.Balance, error) {
+	ctx, cancel := context.WithTimeout(c, b.contextTimeout)
+
+	defer cancel()
+
+	if asOf == nil {
+		return b.balanceRepo.FetchCurrent(ctx, userId, accountId)
+	}
+
+	return b.balanceRepo.FetchAsOf(ctx, userId, accountId, *asOf)
+}
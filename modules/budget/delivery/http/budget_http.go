@@ -0,0 +1,321 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/budget"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type BudgetRequest struct {
+	CategoryID int          `json:"categoryId" validate:"required"`
+	Amount     models.Money `json:"amount" validate:"required"`
+	Period     string       `json:"period" validate:"required,oneof=monthly weekly custom"`
+	StartDate  string       `json:"startDate" validate:"required"`
+	EndDate    string       `json:"endDate"`
+	Rollover   bool         `json:"rollover"`
+	Threshold  int          `json:"threshold"`
+}
+
+type BudgetHandler struct {
+	BudgetUsecase budget.Usecase
+}
+
+func NewBudgetHandler(e *echo.Echo, bu budget.Usecase, middleware *middleware.Middleware) {
+	handler := &BudgetHandler{
+		BudgetUsecase: bu,
+	}
+
+	e.GET("/v1/budget", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/budget/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/budget", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/budget/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/budget/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowBudget godoc
+// @Summary Show List Budget
+// @Description get every budget with budgeted, actual and remaining amounts for the period containing date
+// @Param date query string false "evaluate the period containing this date (YYYY-MM-DD), defaults to today"
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Budget
+// @Header 200 {string} Token "qwerty"
+// @Router /budget [get]
+func (h *BudgetHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	date, err := parseDate(c.QueryParam("date"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	res, err := h.BudgetUsecase.FetchAll(ctx, c.Get("userId").(int), date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowBudget godoc
+// @Summary Show a Budget
+// @Description get budget by ID with budgeted, actual and remaining amounts for the period containing date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Budget id"
+// @Param date query string false "evaluate the period containing this date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.Budget
+// @Header 200 {string} Token "qwerty"
+// @Router /budget/{id} [get]
+func (h *BudgetHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	date, err := parseDate(c.QueryParam("date"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	res, err := h.BudgetUsecase.FetchById(ctx, c.Get("userId").(int), id, date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateBudget godoc
+// @Summary Create a Budget
+// @Description Create a monthly, weekly or custom budget for a category
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param budget body BudgetRequest true "BudgetRequest Body"
+// @Success 201 {object} models.Budget
+// @Header 200 {string} Token "qwerty"
+// @Router /budget [post]
+func (h *BudgetHandler) Create(c echo.Context) error {
+	var req BudgetRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	b, err := toBudget(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	b.UserID = c.Get("userId").(int)
+
+	err = h.BudgetUsecase.Create(ctx, b)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, b)
+}
+
+// UpdateBudget godoc
+// @Summary Update Budget
+// @Description Update budget by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Budget id"
+// @Param budget body BudgetRequest true "BudgetRequest Body"
+// @Success 200 {object} models.Budget
+// @Header 200 {string} Token "qwerty"
+// @Router /budget/{id} [patch]
+func (h *BudgetHandler) Update(c echo.Context) error {
+	var req BudgetRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	b, err := toBudget(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	b.ID = id
+	b.UserID = c.Get("userId").(int)
+
+	res, err := h.BudgetUsecase.Update(ctx, b)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteBudget godoc
+// @Summary Delete Budget
+// @Description Delete budget by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Budget id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /budget/{id} [delete]
+func (h *BudgetHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.BudgetUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseDate reads an optional YYYY-MM-DD query parameter, defaulting to today.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
+
+func toBudget(req *BudgetRequest) (*models.Budget, error) {
+	if ok, err := isRequestValid(req); !ok {
+		return nil, err
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b := &models.Budget{
+		Amount:    req.Amount,
+		Period:    req.Period,
+		StartDate: start,
+		Rollover:  req.Rollover,
+		Threshold: req.Threshold,
+	}
+	b.Category.ID = req.CategoryID
+
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		b.EndDate = &end
+	}
+
+	return b, nil
+}
+
+func isRequestValid(m *BudgetRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
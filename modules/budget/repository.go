@@ -0,0 +1,19 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int) (res []*models.Budget, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Budget, err error)
+	Store(ctx context.Context, b *models.Budget) error
+	Update(ctx context.Context, b *models.Budget) error
+	Delete(ctx context.Context, userId int, id int) error
+	// SpendingByDay sums the net amount of kind ("in" or "out") recorded in the given categories on each day
+	// in [from, to), keyed by the day at midnight UTC.
+	SpendingByDay(ctx context.Context, userId int, categoryIds []int, kind string, from time.Time, to time.Time) (map[time.Time]models.Money, error)
+}
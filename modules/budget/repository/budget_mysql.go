@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/budget"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlBudgetRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlBudgetRepository(Conn *sql.DB) budget.Repository {
+	return &mySqlBudgetRepository{Conn}
+}
+
+func (m *mySqlBudgetRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Budget, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Budget, 0)
+
+	for rows.Next() {
+		b := new(models.Budget)
+		endDate := sql.NullTime{}
+		status := int(0)
+
+		err = rows.Scan(
+			&b.ID,
+			&b.UserID,
+			&b.Category.ID,
+			&b.Category.Name,
+			&b.Category.Type,
+			&b.Amount,
+			&b.Period,
+			&b.StartDate,
+			&endDate,
+			&b.Rollover,
+			&b.Threshold,
+			&status,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if endDate.Valid {
+			b.EndDate = &endDate.Time
+		}
+
+		if status == 1 {
+			b.Status = "active"
+		} else {
+			b.Status = "inactive"
+		}
+
+		result = append(result, b)
+	}
+
+	return result, nil
+}
+
+func nullableDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return t.Format("2006-01-02")
+}
+
+func (m *mySqlBudgetRepository) FetchAll(ctx context.Context, userId int) (res []*models.Budget, err error) {
+	query := `SELECT b.id, b.user_id, b.category_id, c.name, c.type, b.amount, b.period, b.start_date, b.end_date, b.rollover, b.threshold, b.status, b.created_at, b.updated_at
+		FROM budgets b JOIN categories c ON b.category_id=c.id WHERE b.status=1 AND b.user_id = ? ORDER BY c.name`
+
+	return m.fetch(ctx, query, userId)
+}
+
+func (m *mySqlBudgetRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Budget, err error) {
+	query := `SELECT b.id, b.user_id, b.category_id, c.name, c.type, b.amount, b.period, b.start_date, b.end_date, b.rollover, b.threshold, b.status, b.created_at, b.updated_at
+		FROM budgets b JOIN categories c ON b.category_id=c.id WHERE b.status=1 AND b.user_id = ? AND b.id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlBudgetRepository) Store(ctx context.Context, b *models.Budget) error {
+	query := `INSERT budgets SET user_id=?, category_id=?, amount=?, period=?, start_date=?, end_date=?, rollover=?, threshold=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, b.UserID, b.Category.ID, b.Amount, b.Period, b.StartDate.Format("2006-01-02"), nullableDate(b.EndDate), b.Rollover, b.Threshold, b.CreatedAt, b.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	b.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlBudgetRepository) Update(ctx context.Context, b *models.Budget) error {
+	query := `UPDATE budgets SET category_id=?, amount=?, period=?, start_date=?, end_date=?, rollover=?, threshold=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, b.Category.ID, b.Amount, b.Period, b.StartDate.Format("2006-01-02"), nullableDate(b.EndDate), b.Rollover, b.Threshold, b.UpdatedAt, b.UserID, b.ID)
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlBudgetRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE budgets SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
+
+func (m *mySqlBudgetRepository) SpendingByDay(ctx context.Context, userId int, categoryIds []int, kind string, from time.Time, to time.Time) (map[time.Time]models.Money, error) {
+	ids := make([]string, len(categoryIds))
+
+	for i, id := range categoryIds {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	net := "t.amount_out - t.amount_in"
+	splitNet := "CASE WHEN t.amount_out > 0 THEN s.amount ELSE -s.amount END"
+
+	if kind == "in" {
+		net = "t.amount_in - t.amount_out"
+		splitNet = "CASE WHEN t.amount_in > 0 THEN s.amount ELSE -s.amount END"
+	}
+
+	// Split transactions count through their splits instead of their own category.
+	query := fmt.Sprintf(`SELECT trx_date, SUM(amount) FROM (
+			SELECT t.trx_date, %s AS amount FROM transactions t
+			WHERE t.status=1 AND t.user_id = ? AND t.category_id IN (%s) AND t.trx_date >= ? AND t.trx_date < ?
+			AND NOT EXISTS (SELECT 1 FROM transaction_splits x WHERE x.transaction_id=t.id)
+			UNION ALL
+			SELECT t.trx_date, %s AS amount FROM transaction_splits s JOIN transactions t ON s.transaction_id=t.id
+			WHERE t.status=1 AND t.user_id = ? AND s.category_id IN (%s) AND t.trx_date >= ? AND t.trx_date < ?
+		) spending GROUP BY trx_date`, net, strings.Join(ids, ","), splitNet, strings.Join(ids, ","))
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId, from.Format("2006-01-02"), to.Format("2006-01-02"), userId, from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make(map[time.Time]models.Money)
+
+	for rows.Next() {
+		var day time.Time
+		var amount models.Money
+
+		err = rows.Scan(&day, &amount)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result[day.UTC()] = amount
+	}
+
+	return result, nil
+}
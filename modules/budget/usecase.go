@@ -0,0 +1,17 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// FetchAll and FetchById evaluate each budget for the period containing date.
+	FetchAll(c context.Context, userId int, date time.Time) ([]*models.Budget, error)
+	FetchById(c context.Context, userId int, id int, date time.Time) (*models.Budget, error)
+	Create(c context.Context, b *models.Budget) error
+	Update(c context.Context, b *models.Budget) (*models.Budget, error)
+	Delete(c context.Context, userId int, id int) error
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/budget"
+	"github.com/arham09/fin-api/modules/category"
+)
+
+// defaultThreshold is the share of the budget, in percent, that flags a budget when no threshold is given.
+const defaultThreshold = 80
+
+type budgetUsecase struct {
+	budgetRepo     budget.Repository
+	categoryRepo   category.Repository
+	contextTimeout time.Duration
+}
+
+func NewBudgetUsecase(b budget.Repository, c category.Repository, timeout time.Duration) budget.Usecase {
+	return &budgetUsecase{
+		budgetRepo:     b,
+		categoryRepo:   c,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *budgetUsecase) validate(ctx context.Context, b *models.Budget) error {
+	if b.Amount <= 0 || b.Threshold < 0 || b.Threshold > 100 || b.StartDate.IsZero() {
+		return helpers.ErrBadParamInput
+	}
+
+	switch b.Period {
+	case "monthly", "weekly":
+		b.EndDate = nil
+	case "custom":
+		if b.EndDate == nil || b.EndDate.Before(b.StartDate) {
+			return helpers.ErrBadParamInput
+		}
+	default:
+		return helpers.ErrBadParamInput
+	}
+
+	if b.Threshold == 0 {
+		b.Threshold = defaultThreshold
+	}
+
+	cat, err := u.categoryRepo.FetchById(ctx, b.UserID, b.Category.ID)
+
+	if err != nil {
+		return err
+	}
+
+	b.Category = *cat
+
+	return nil
+}
+
+// evaluate fills the progress of the budget for the period containing date. Spending is counted in the budget
+// category and every category below it; with rollover, the unspent part of each period is added to the next.
+func (u *budgetUsecase) evaluate(ctx context.Context, b *models.Budget, date time.Time) error {
+	list, err := u.categoryRepo.FetchAll(ctx, b.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	day := midnight(date)
+	first := firstPeriod(b)
+	current := first
+
+	for !day.Before(current.end) {
+		current = next(b, current)
+	}
+
+	spending, err := u.budgetRepo.SpendingByDay(ctx, b.UserID, category.Descendants(list, b.Category.ID), b.Category.Type, first.start, current.end)
+
+	if err != nil {
+		return err
+	}
+
+	var carried models.Money
+
+	for p := first; ; p = next(b, p) {
+		var actual models.Money
+
+		for spentOn, amount := range spending {
+			if p.contains(spentOn) {
+				actual += amount
+			}
+		}
+
+		budgeted := b.Amount + carried
+
+		if p.start.Equal(current.start) {
+			b.Progress = &models.BudgetProgress{
+				PeriodStart:   p.start,
+				PeriodEnd:     p.end.AddDate(0, 0, -1),
+				Carried:       carried,
+				Budgeted:      budgeted,
+				Actual:        actual,
+				Remaining:     budgeted - actual,
+				OverThreshold: int64(actual)*100 >= int64(budgeted)*int64(b.Threshold),
+				OverSpent:     actual > budgeted,
+			}
+
+			return nil
+		}
+
+		carried = 0
+
+		if b.Rollover && actual < budgeted {
+			carried = budgeted - actual
+		}
+	}
+}
+
+func (u *budgetUsecase) FetchAll(c context.Context, userId int, date time.Time) ([]*models.Budget, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.budgetRepo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range res {
+		if err = u.evaluate(ctx, b, date); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (u *budgetUsecase) FetchById(c context.Context, userId int, id int, date time.Time) (*models.Budget, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.budgetRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.evaluate(ctx, res, date); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *budgetUsecase) Create(c context.Context, b *models.Budget) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := u.validate(ctx, b); err != nil {
+		return err
+	}
+
+	b.CreatedAt = time.Now()
+	b.UpdatedAt = time.Now()
+
+	return u.budgetRepo.Store(ctx, b)
+}
+
+func (u *budgetUsecase) Update(c context.Context, b *models.Budget) (*models.Budget, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.budgetRepo.FetchById(ctx, b.UserID, b.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validate(ctx, b); err != nil {
+		return nil, err
+	}
+
+	b.UpdatedAt = time.Now()
+
+	err = u.budgetRepo.Update(ctx, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.budgetRepo.FetchById(ctx, b.UserID, b.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.evaluate(ctx, res, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *budgetUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.budgetRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.budgetRepo.Delete(ctx, userId, id)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// period is a half-open range of days [start, end).
+type period struct {
+	start time.Time
+	end   time.Time
+}
+
+func (p period) contains(day time.Time) bool {
+	return !day.Before(p.start) && day.Before(p.end)
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// firstPeriod returns the period the budget starts in. Monthly budgets follow calendar months, weekly budgets
+// run for seven days from their start date and custom budgets repeat the range between their start and end dates.
+func firstPeriod(b *models.Budget) period {
+	start := midnight(b.StartDate)
+
+	switch b.Period {
+	case "monthly":
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return period{start, start.AddDate(0, 1, 0)}
+	case "weekly":
+		return period{start, start.AddDate(0, 0, 7)}
+	default:
+		return period{start, midnight(*b.EndDate).AddDate(0, 0, 1)}
+	}
+}
+
+// next returns the period that follows p for the budget.
+func next(b *models.Budget, p period) period {
+	if b.Period == "monthly" {
+		return period{p.end, p.end.AddDate(0, 1, 0)}
+	}
+
+	return period{p.end, p.end.Add(p.end.Sub(p.start))}
+}
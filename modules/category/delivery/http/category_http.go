@@ -0,0 +1,246 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CategoryHandler struct {
+	CategoryUsecase category.Usecase
+}
+
+func NewCategoryHandler(e *echo.Echo, cu category.Usecase, middleware *middleware.Middleware) {
+	handler := &CategoryHandler{
+		CategoryUsecase: cu,
+	}
+
+	e.GET("/v1/category", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/category/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/category", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/category/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/category/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowCategory godoc
+// @Summary Show List Category
+// @Description get every category of the user, children refer to their parent through parentId
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Category
+// @Header 200 {string} Token "qwerty"
+// @Router /category [get]
+func (h *CategoryHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.CategoryUsecase.FetchAll(ctx, c.Get("userId").(int))
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowCategory godoc
+// @Summary Show a Category
+// @Description get category by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Category id"
+// @Success 200 {object} models.Category
+// @Header 200 {string} Token "qwerty"
+// @Router /category/{id} [get]
+func (h *CategoryHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.CategoryUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateCategory godoc
+// @Summary Create a Category
+// @Description Create Category, optionally below a parent of the same type
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param category body models.Category true "models.Category without ID"
+// @Success 201 {object} models.Category
+// @Header 200 {string} Token "qwerty"
+// @Router /category [post]
+func (h *CategoryHandler) Create(c echo.Context) error {
+	var cat models.Category
+
+	err := c.Bind(&cat)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&cat); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cat.UserID = c.Get("userId").(int)
+
+	err = h.CategoryUsecase.Create(ctx, &cat)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, cat)
+}
+
+// UpdateCategory godoc
+// @Summary Update Category
+// @Description Update category by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Category id"
+// @Param category body models.Category true "models.Category without ID"
+// @Success 200 {object} models.Category
+// @Header 200 {string} Token "qwerty"
+// @Router /category/{id} [patch]
+func (h *CategoryHandler) Update(c echo.Context) error {
+	var cat models.Category
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	err = c.Bind(&cat)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&cat); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cat.ID = id
+	cat.UserID = c.Get("userId").(int)
+
+	res, err := h.CategoryUsecase.Update(ctx, &cat)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteCategory godoc
+// @Summary Delete Category
+// @Description Delete a category without children by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Category id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /category/{id} [delete]
+func (h *CategoryHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.CategoryUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func isRequestValid(m *models.Category) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
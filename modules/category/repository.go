@@ -0,0 +1,15 @@
+package category
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int) (res []*models.Category, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Category, err error)
+	Store(ctx context.Context, c *models.Category) error
+	Update(ctx context.Context, c *models.Category) error
+	Delete(ctx context.Context, userId int, id int) error
+}
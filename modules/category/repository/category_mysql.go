@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlCategoryRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlCategoryRepository(Conn *sql.DB) category.Repository {
+	return &mySqlCategoryRepository{Conn}
+}
+
+func (m *mySqlCategoryRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Category, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Category, 0)
+
+	for rows.Next() {
+		t := new(models.Category)
+		parentId := sql.NullInt64{}
+		status := int(0)
+
+		err = rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&parentId,
+			&t.Name,
+			&t.Type,
+			&status,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if parentId.Valid {
+			t.ParentID = int(parentId.Int64)
+		}
+
+		if status == 1 {
+			t.Status = "active"
+		} else {
+			t.Status = "inactive"
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// nullableParent stores root categories with a NULL parent.
+func nullableParent(parentId int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(parentId), Valid: parentId != 0}
+}
+
+// StoreDefaults stores the default categories of a newly registered user through tx, so that they are stored if and
+// only if the user is.
+func StoreDefaults(ctx context.Context, tx *sql.Tx, userId int) error {
+	query := `INSERT categories SET user_id=?, parent_id=?, name=?, type=?, created_at=?, updated_at=?`
+
+	return category.SeedDefaults(userId, func(c *models.Category) error {
+		res, err := tx.ExecContext(ctx, query, c.UserID, nullableParent(c.ParentID), c.Name, c.Type, c.CreatedAt, c.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		c.ID = int(lastID)
+
+		return nil
+	})
+}
+
+func (m *mySqlCategoryRepository) FetchAll(ctx context.Context, userId int) (res []*models.Category, err error) {
+	query := `SELECT id, user_id, parent_id, name, type, status, created_at, updated_at FROM categories WHERE status=1 AND user_id = ? ORDER BY type, parent_id, name`
+
+	return m.fetch(ctx, query, userId)
+}
+
+func (m *mySqlCategoryRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Category, err error) {
+	query := `SELECT id, user_id, parent_id, name, type, status, created_at, updated_at FROM categories WHERE status=1 AND user_id = ? AND id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlCategoryRepository) Store(ctx context.Context, c *models.Category) error {
+	query := `INSERT categories SET user_id=?, parent_id=?, name=?, type=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, c.UserID, nullableParent(c.ParentID), c.Name, c.Type, c.CreatedAt, c.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	c.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlCategoryRepository) Update(ctx context.Context, c *models.Category) error {
+	query := `UPDATE categories SET parent_id=?, name=?, type=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableParent(c.ParentID), c.Name, c.Type, c.UpdatedAt, c.UserID, c.ID)
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlCategoryRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE categories SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
@@ -0,0 +1,93 @@
+package category
+
+import (
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Descendants returns id followed by the ids of every category below it in list.
+func Descendants(list []*models.Category, id int) []int {
+	children := make(map[int][]int)
+
+	for _, c := range list {
+		children[c.ParentID] = append(children[c.ParentID], c.ID)
+	}
+
+	res := []int{id}
+
+	for i := 0; i < len(res); i++ {
+		res = append(res, children[res[i]]...)
+	}
+
+	return res
+}
+
+// Paths returns the full name of every category in list, its ancestors' names first, joined by sep.
+func Paths(list []*models.Category, sep string) map[int]string {
+	byId := make(map[int]*models.Category, len(list))
+
+	for _, c := range list {
+		byId[c.ID] = c
+	}
+
+	res := make(map[int]string, len(list))
+
+	for _, c := range list {
+		path := c.Name
+
+		// The depth limit guards against a corrupted tree with a cycle.
+		for p, depth := byId[c.ParentID], 0; p != nil && depth < len(list); p, depth = byId[p.ParentID], depth+1 {
+			path = p.Name + sep + path
+		}
+
+		res[c.ID] = path
+	}
+
+	return res
+}
+
+type defaultCategory struct {
+	name     string
+	kind     string
+	children []string
+}
+
+// defaults are the categories every user starts with.
+var defaults = []defaultCategory{
+	{"Salary", "in", nil},
+	{"Business", "in", nil},
+	{"Investment", "in", []string{"Interest", "Dividends"}},
+	{"Other Income", "in", nil},
+	{"Food & Drinks", "out", []string{"Groceries", "Restaurants"}},
+	{"Housing", "out", []string{"Rent", "Utilities"}},
+	{"Transportation", "out", []string{"Fuel", "Public Transport"}},
+	{"Shopping", "out", nil},
+	{"Health", "out", nil},
+	{"Entertainment", "out", nil},
+	{"Other Expense", "out", nil},
+}
+
+// SeedDefaults builds the default income and expense categories for a newly registered user and hands each to store,
+// parents before their children so that store can set the ID a child refers to.
+func SeedDefaults(userId int, store func(c *models.Category) error) error {
+	now := time.Now()
+
+	for _, d := range defaults {
+		parent := &models.Category{UserID: userId, Name: d.name, Type: d.kind, CreatedAt: now, UpdatedAt: now}
+
+		if err := store(parent); err != nil {
+			return err
+		}
+
+		for _, name := range d.children {
+			child := &models.Category{UserID: userId, ParentID: parent.ID, Name: name, Type: d.kind, CreatedAt: now, UpdatedAt: now}
+
+			if err := store(child); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
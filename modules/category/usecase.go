@@ -0,0 +1,15 @@
+package category
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int) ([]*models.Category, error)
+	FetchById(c context.Context, userId int, id int) (*models.Category, error)
+	Create(c context.Context, cat *models.Category) error
+	Update(c context.Context, cat *models.Category) (*models.Category, error)
+	Delete(c context.Context, userId int, id int) error
+}
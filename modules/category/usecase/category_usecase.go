@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/category"
+)
+
+type categoryUsecase struct {
+	categoryRepo   category.Repository
+	contextTimeout time.Duration
+}
+
+func NewCategoryUsecase(c category.Repository, timeout time.Duration) category.Usecase {
+	return &categoryUsecase{
+		categoryRepo:   c,
+		contextTimeout: timeout,
+	}
+}
+
+// validateParent checks that the parent exists and holds the same kind of transactions as cat.
+func (u *categoryUsecase) validateParent(ctx context.Context, cat *models.Category) error {
+	if cat.ParentID == 0 {
+		return nil
+	}
+
+	parent, err := u.categoryRepo.FetchById(ctx, cat.UserID, cat.ParentID)
+
+	if err != nil {
+		return err
+	}
+
+	if parent.Type != cat.Type {
+		return helpers.ErrBadParamInput
+	}
+
+	return nil
+}
+
+func (u *categoryUsecase) FetchAll(c context.Context, userId int) ([]*models.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.categoryRepo.FetchAll(ctx, userId)
+}
+
+func (u *categoryUsecase) FetchById(c context.Context, userId int, id int) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.categoryRepo.FetchById(ctx, userId, id)
+}
+
+func (u *categoryUsecase) Create(c context.Context, cat *models.Category) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := u.validateParent(ctx, cat); err != nil {
+		return err
+	}
+
+	cat.CreatedAt = time.Now()
+	cat.UpdatedAt = time.Now()
+
+	return u.categoryRepo.Store(ctx, cat)
+}
+
+func (u *categoryUsecase) Update(c context.Context, cat *models.Category) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	existing, err := u.categoryRepo.FetchById(ctx, cat.UserID, cat.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := u.categoryRepo.FetchAll(ctx, cat.UserID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subtree := category.Descendants(list, cat.ID)
+
+	// A category cannot move below itself, and its children must keep sharing its type.
+	for _, id := range subtree {
+		if id == cat.ParentID {
+			return nil, helpers.ErrBadParamInput
+		}
+	}
+
+	if len(subtree) > 1 && existing.Type != cat.Type {
+		return nil, helpers.ErrConflict
+	}
+
+	if err = u.validateParent(ctx, cat); err != nil {
+		return nil, err
+	}
+
+	cat.UpdatedAt = time.Now()
+
+	err = u.categoryRepo.Update(ctx, cat)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.categoryRepo.FetchById(ctx, cat.UserID, cat.ID)
+}
+
+func (u *categoryUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.categoryRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	list, err := u.categoryRepo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return err
+	}
+
+	if len(category.Descendants(list, id)) > 1 {
+		return helpers.ErrConflict
+	}
+
+	return u.categoryRepo.Delete(ctx, userId, id)
+}
@@ -0,0 +1,73 @@
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+)
+
+// Converter converts amounts between currencies using the rates stored by a user, caching the rate looked up
+// for each pair and day. It is meant to live for a single request.
+type Converter struct {
+	repo   Repository
+	userId int
+	cache  map[string]*models.ExchangeRate
+}
+
+func NewConverter(repo Repository, userId int) *Converter {
+	return &Converter{
+		repo:   repo,
+		userId: userId,
+		cache:  make(map[string]*models.ExchangeRate),
+	}
+}
+
+func (c *Converter) lookup(ctx context.Context, base string, quote string, date time.Time) (*models.ExchangeRate, error) {
+	key := base + quote + date.Format("2006-01-02")
+
+	if res, ok := c.cache[key]; ok {
+		return res, nil
+	}
+
+	res, err := c.repo.FetchEffective(ctx, c.userId, base, quote, date)
+
+	if err != nil && err != helpers.ErrNotFound {
+		return nil, err
+	}
+
+	c.cache[key] = res
+
+	return res, nil
+}
+
+// Convert converts amount from one currency to another with the rate valid on date, using the inverse pair
+// when only that one is stored.
+func (c *Converter) Convert(ctx context.Context, amount models.Money, from string, to string, date time.Time) (models.Money, error) {
+	if from == to || amount == 0 {
+		return amount, nil
+	}
+
+	direct, err := c.lookup(ctx, from, to, date)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if direct != nil {
+		return amount.Mul(direct.Rate), nil
+	}
+
+	inverse, err := c.lookup(ctx, to, from, date)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if inverse != nil {
+		return amount.Div(inverse.Rate), nil
+	}
+
+	return 0, helpers.ErrRateNotFound
+}
@@ -0,0 +1,359 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/exchangerate"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type RateRequest struct {
+	BaseCurrency  string      `json:"baseCurrency" validate:"required,len=3"`
+	QuoteCurrency string      `json:"quoteCurrency" validate:"required,len=3"`
+	Rate          models.Rate `json:"rate" validate:"required"`
+	Date          string      `json:"date" validate:"required"`
+}
+
+type ExchangeRateHandler struct {
+	RateUsecase exchangerate.Usecase
+}
+
+func NewExchangeRateHandler(e *echo.Echo, ru exchangerate.Usecase, middleware *middleware.Middleware) {
+	handler := &ExchangeRateHandler{
+		RateUsecase: ru,
+	}
+
+	e.GET("/v1/rate", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/rate/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/rate", handler.Create, middleware.Authorize)
+	e.POST("/v1/rate/import", handler.Import, middleware.Authorize)
+	e.PATCH("/v1/rate/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/rate/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowRate godoc
+// @Summary Show List Exchange Rate
+// @Description get list exchange rate, newest first
+// @Param base query string false "filter by base currency"
+// @Param quote query string false "filter by quote currency"
+// @Param limit query int true "limit list"
+// @Param offset query int true "offset list"
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.ExchangeRate in data
+// @Header 200 {string} Token "qwerty"
+// @Router /rate [get]
+func (h *ExchangeRateHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	filter := make(map[string]interface{})
+
+	for key, param := range c.QueryParams() {
+		if key != "limit" && key != "offset" {
+			filter[key] = param[0]
+		}
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	res, total, err := h.RateUsecase.FetchAll(ctx, userId, filter, limit, offset)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  res,
+		"total": total,
+	})
+}
+
+// ShowRate godoc
+// @Summary Show an Exchange Rate
+// @Description get exchange rate by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Exchange rate id"
+// @Success 200 {object} models.ExchangeRate
+// @Header 200 {string} Token "qwerty"
+// @Router /rate/{id} [get]
+func (h *ExchangeRateHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.RateUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateRate godoc
+// @Summary Create an Exchange Rate
+// @Description Store how much one unit of the base currency is worth in the quote currency from the given date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param rate body RateRequest true "RateRequest Body"
+// @Success 201 {object} models.ExchangeRate
+// @Header 200 {string} Token "qwerty"
+// @Router /rate [post]
+func (h *ExchangeRateHandler) Create(c echo.Context) error {
+	var req RateRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	rate, err := toExchangeRate(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	rate.UserID = c.Get("userId").(int)
+
+	err = h.RateUsecase.Create(ctx, rate)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// ImportRate godoc
+// @Summary Import Exchange Rates
+// @Description Import rates from a CSV file with date (YYYY-MM-DD), base, quote and rate columns
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param file formData file true "CSV file"
+// @Success 200 {object} map[string]interface{}
+// @Header 200 {string} Token "qwerty"
+// @Router /rate/import [post]
+func (h *ExchangeRateHandler) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	imported, rowErrors, err := h.RateUsecase.Import(ctx, c.Get("userId").(int), src)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"imported": imported,
+		"errors":   rowErrors,
+	})
+}
+
+// UpdateRate godoc
+// @Summary Update Exchange Rate
+// @Description Update exchange rate by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Exchange rate id"
+// @Param rate body RateRequest true "RateRequest Body"
+// @Success 200 {object} models.ExchangeRate
+// @Header 200 {string} Token "qwerty"
+// @Router /rate/{id} [patch]
+func (h *ExchangeRateHandler) Update(c echo.Context) error {
+	var req RateRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	rate, err := toExchangeRate(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	rate.ID = id
+	rate.UserID = c.Get("userId").(int)
+
+	res, err := h.RateUsecase.Update(ctx, rate)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteRate godoc
+// @Summary Delete Exchange Rate
+// @Description Delete exchange rate by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Exchange rate id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /rate/{id} [delete]
+func (h *ExchangeRateHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.RateUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toExchangeRate(req *RateRequest) (*models.ExchangeRate, error) {
+	if ok, err := isRequestValid(req); !ok {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExchangeRate{
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Rate:          req.Rate,
+		Date:          date,
+	}, nil
+}
+
+func isRequestValid(m *RateRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,20 @@
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int, filters map[string]interface{}, limit int, offset int) (res []*models.ExchangeRate, total int, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.ExchangeRate, err error)
+	// FetchEffective returns the latest base/quote rate dated on or before date.
+	FetchEffective(ctx context.Context, userId int, base string, quote string, date time.Time) (res *models.ExchangeRate, err error)
+	Store(ctx context.Context, r *models.ExchangeRate) error
+	// StoreBatch inserts rates in one database transaction, replacing rates already stored for the same pair and date.
+	StoreBatch(ctx context.Context, rates []*models.ExchangeRate) error
+	Update(ctx context.Context, r *models.ExchangeRate) error
+	Delete(ctx context.Context, userId int, id int) error
+}
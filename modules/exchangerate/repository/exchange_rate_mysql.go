@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/exchangerate"
+	"github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
+)
+
+// errDuplicateEntry is the MySQL error number for a unique key violation.
+const errDuplicateEntry = 1062
+
+// filterColumns maps the accepted query filters to their columns.
+var filterColumns = map[string]string{
+	"base":  "base_currency",
+	"quote": "quote_currency",
+}
+
+// upsertRate stores a rate, reviving or replacing the row already kept for the same pair and date.
+const upsertRate = `INSERT INTO exchange_rates (user_id, base_currency, quote_currency, rate, rate_date, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id), rate=VALUES(rate), status=1, updated_at=VALUES(updated_at)`
+
+type mySqlExchangeRateRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlExchangeRateRepository(Conn *sql.DB) exchangerate.Repository {
+	return &mySqlExchangeRateRepository{Conn}
+}
+
+func (m *mySqlExchangeRateRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.ExchangeRate, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.ExchangeRate, 0)
+
+	for rows.Next() {
+		r := new(models.ExchangeRate)
+
+		err = rows.Scan(
+			&r.ID,
+			&r.UserID,
+			&r.BaseCurrency,
+			&r.QuoteCurrency,
+			&r.Rate,
+			&r.Date,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlExchangeRateRepository) FetchAll(ctx context.Context, userId int, filters map[string]interface{}, limit int, offset int) (res []*models.ExchangeRate, total int, err error) {
+	query := `SELECT id, user_id, base_currency, quote_currency, rate, rate_date, created_at, updated_at FROM exchange_rates WHERE status=1 AND user_id=?`
+	countQuery := `SELECT COUNT(id) FROM exchange_rates WHERE status=1 AND user_id=?`
+	args := []interface{}{userId}
+
+	for filter, param := range filters {
+		column, ok := filterColumns[filter]
+
+		if !ok {
+			continue
+		}
+
+		query = query + fmt.Sprintf(" AND %s = ?", column)
+		countQuery = countQuery + fmt.Sprintf(" AND %s = ?", column)
+		args = append(args, param)
+	}
+
+	err = m.Conn.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	query = query + fmt.Sprintf(" ORDER BY rate_date DESC, id DESC LIMIT %d OFFSET %d", limit, offset)
+
+	list, err := m.fetch(ctx, query, args...)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+func (m *mySqlExchangeRateRepository) FetchById(ctx context.Context, userId int, id int) (res *models.ExchangeRate, err error) {
+	query := `SELECT id, user_id, base_currency, quote_currency, rate, rate_date, created_at, updated_at FROM exchange_rates WHERE status=1 AND user_id = ? AND id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlExchangeRateRepository) FetchEffective(ctx context.Context, userId int, base string, quote string, date time.Time) (res *models.ExchangeRate, err error) {
+	query := `SELECT id, user_id, base_currency, quote_currency, rate, rate_date, created_at, updated_at FROM exchange_rates
+		WHERE status=1 AND user_id = ? AND base_currency = ? AND quote_currency = ? AND rate_date <= ? ORDER BY rate_date DESC LIMIT 1`
+
+	list, err := m.fetch(ctx, query, userId, base, quote, date.Format("2006-01-02"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlExchangeRateRepository) store(ctx context.Context, exec func(ctx context.Context, query string, args ...interface{}) (sql.Result, error), r *models.ExchangeRate) error {
+	res, err := exec(ctx, upsertRate, r.UserID, r.BaseCurrency, r.QuoteCurrency, r.Rate, r.Date.Format("2006-01-02"), r.CreatedAt, r.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	r.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlExchangeRateRepository) Store(ctx context.Context, r *models.ExchangeRate) error {
+	return m.store(ctx, m.Conn.ExecContext, r)
+}
+
+func (m *mySqlExchangeRateRepository) StoreBatch(ctx context.Context, rates []*models.ExchangeRate) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rates {
+		err = m.store(ctx, tx.ExecContext, r)
+
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logrus.Error(rbErr)
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlExchangeRateRepository) Update(ctx context.Context, r *models.ExchangeRate) error {
+	query := `UPDATE exchange_rates SET base_currency=?, quote_currency=?, rate=?, rate_date=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, r.BaseCurrency, r.QuoteCurrency, r.Rate, r.Date.Format("2006-01-02"), r.UpdatedAt, r.UserID, r.ID)
+	if me, ok := err.(*mysql.MySQLError); ok && me.Number == errDuplicateEntry {
+		return helpers.ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlExchangeRateRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE exchange_rates SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
@@ -0,0 +1,19 @@
+package exchangerate
+
+import (
+	"context"
+	"io"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int, filter map[string]interface{}, limit int, offset int) ([]*models.ExchangeRate, int, error)
+	FetchById(c context.Context, userId int, id int) (*models.ExchangeRate, error)
+	Create(c context.Context, r *models.ExchangeRate) error
+	Update(c context.Context, r *models.ExchangeRate) (*models.ExchangeRate, error)
+	Delete(c context.Context, userId int, id int) error
+	// Import reads date,base,quote,rate rows from a CSV file and returns how many were stored along with the
+	// problems found in the rows that were skipped.
+	Import(c context.Context, userId int, r io.Reader) (int, []*models.ImportError, error)
+}
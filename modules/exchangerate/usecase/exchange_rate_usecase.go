@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/exchangerate"
+)
+
+type exchangeRateUsecase struct {
+	rateRepo       exchangerate.Repository
+	contextTimeout time.Duration
+}
+
+func NewExchangeRateUsecase(r exchangerate.Repository, timeout time.Duration) exchangerate.Usecase {
+	return &exchangeRateUsecase{
+		rateRepo:       r,
+		contextTimeout: timeout,
+	}
+}
+
+// normalize upper-cases the currency codes and checks that the rate converts between two currencies.
+func normalize(r *models.ExchangeRate) error {
+	r.BaseCurrency = strings.ToUpper(strings.TrimSpace(r.BaseCurrency))
+	r.QuoteCurrency = strings.ToUpper(strings.TrimSpace(r.QuoteCurrency))
+
+	if err := helpers.VerifyCurrency(r.BaseCurrency); err != nil {
+		return err
+	}
+
+	if err := helpers.VerifyCurrency(r.QuoteCurrency); err != nil {
+		return err
+	}
+
+	if r.BaseCurrency == r.QuoteCurrency || r.Rate <= 0 || r.Date.IsZero() {
+		return helpers.ErrBadParamInput
+	}
+
+	return nil
+}
+
+func (e *exchangeRateUsecase) FetchAll(c context.Context, userId int, filter map[string]interface{}, limit int, offset int) ([]*models.ExchangeRate, int, error) {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	res, total, err := e.rateRepo.FetchAll(ctx, userId, filter, limit, offset)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, total, nil
+}
+
+func (e *exchangeRateUsecase) FetchById(c context.Context, userId int, id int) (*models.ExchangeRate, error) {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	return e.rateRepo.FetchById(ctx, userId, id)
+}
+
+func (e *exchangeRateUsecase) Create(c context.Context, r *models.ExchangeRate) error {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	if err := normalize(r); err != nil {
+		return err
+	}
+
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+
+	return e.rateRepo.Store(ctx, r)
+}
+
+func (e *exchangeRateUsecase) Update(c context.Context, r *models.ExchangeRate) (*models.ExchangeRate, error) {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	_, err := e.rateRepo.FetchById(ctx, r.UserID, r.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = normalize(r); err != nil {
+		return nil, err
+	}
+
+	r.UpdatedAt = time.Now()
+
+	err = e.rateRepo.Update(ctx, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.rateRepo.FetchById(ctx, r.UserID, r.ID)
+}
+
+func (e *exchangeRateUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	_, err := e.rateRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return e.rateRepo.Delete(ctx, userId, id)
+}
+
+func (e *exchangeRateUsecase) Import(c context.Context, userId int, r io.Reader) (int, []*models.ImportError, error) {
+	ctx, cancel := context.WithTimeout(c, e.contextTimeout)
+
+	defer cancel()
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	rates := make([]*models.ExchangeRate, 0)
+	rowErrors := make([]*models.ImportError, 0)
+	now := time.Now()
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		// The header row is optional.
+		if row == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		rate, err := parseRecord(record)
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		rate.UserID = userId
+		rate.CreatedAt = now
+		rate.UpdatedAt = now
+
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	err := e.rateRepo.StoreBatch(ctx, rates)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(rates), rowErrors, nil
+}
+
+// parseRecord reads a date,base,quote,rate CSV record.
+func parseRecord(record []string) (*models.ExchangeRate, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+
+	if err != nil {
+		return nil, fmt.Errorf("date should be formatted as YYYY-MM-DD")
+	}
+
+	rate, err := models.ParseRate(record[3])
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ExchangeRate{
+		BaseCurrency:  record[1],
+		QuoteCurrency: record[2],
+		Rate:          rate,
+		Date:          date,
+	}
+
+	if err = normalize(res); err != nil {
+		return nil, fmt.Errorf("row should convert between two ISO 4217 currencies with a positive rate")
+	}
+
+	return res, nil
+}
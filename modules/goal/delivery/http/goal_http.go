@@ -0,0 +1,313 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/goal"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type GoalRequest struct {
+	Name         string       `json:"name" validate:"required,max=55"`
+	TargetAmount models.Money `json:"targetAmount" validate:"required"`
+	TargetDate   string       `json:"targetDate" validate:"required"`
+	Currency     string       `json:"currency"`
+	AccountIDs   []int        `json:"accountIds" validate:"required,min=1"`
+}
+
+type GoalHandler struct {
+	GoalUsecase goal.Usecase
+}
+
+func NewGoalHandler(e *echo.Echo, gu goal.Usecase, middleware *middleware.Middleware) {
+	handler := &GoalHandler{
+		GoalUsecase: gu,
+	}
+
+	e.GET("/v1/goal", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/goal/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/goal", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/goal/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/goal/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowGoal godoc
+// @Summary Show List Goal
+// @Description get every savings goal with its progress on date: amount saved in the linked accounts, the monthly contribution still required to reach the target on time and the completion projected from the contributions of the last three complete months
+// @Param date query string false "evaluate the goals on this date (YYYY-MM-DD), defaults to today"
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Goal
+// @Header 200 {string} Token "qwerty"
+// @Router /goal [get]
+func (h *GoalHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	date, err := parseDate(c.QueryParam("date"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	res, err := h.GoalUsecase.FetchAll(ctx, c.Get("userId").(int), date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowGoal godoc
+// @Summary Show a Goal
+// @Description get savings goal by ID with its progress on date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Goal id"
+// @Param date query string false "evaluate the goal on this date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.Goal
+// @Header 200 {string} Token "qwerty"
+// @Router /goal/{id} [get]
+func (h *GoalHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	date, err := parseDate(c.QueryParam("date"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	res, err := h.GoalUsecase.FetchById(ctx, c.Get("userId").(int), id, date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateGoal godoc
+// @Summary Create a Goal
+// @Description Create a savings goal reaching targetAmount by targetDate (YYYY-MM-DD) through the balance of the linked accounts. Amounts are counted in currency, which defaults to IDR
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param goal body GoalRequest true "GoalRequest Body"
+// @Success 201 {object} models.Goal
+// @Header 200 {string} Token "qwerty"
+// @Router /goal [post]
+func (h *GoalHandler) Create(c echo.Context) error {
+	var req GoalRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	g, err := toGoal(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	g.UserID = c.Get("userId").(int)
+
+	err = h.GoalUsecase.Create(ctx, g)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, g)
+}
+
+// UpdateGoal godoc
+// @Summary Update Goal
+// @Description Update goal by ID, replacing its linked accounts
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Goal id"
+// @Param goal body GoalRequest true "GoalRequest Body"
+// @Success 200 {object} models.Goal
+// @Header 200 {string} Token "qwerty"
+// @Router /goal/{id} [patch]
+func (h *GoalHandler) Update(c echo.Context) error {
+	var req GoalRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	g, err := toGoal(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	g.ID = id
+	g.UserID = c.Get("userId").(int)
+
+	res, err := h.GoalUsecase.Update(ctx, g)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteGoal godoc
+// @Summary Delete Goal
+// @Description Delete goal by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Goal id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /goal/{id} [delete]
+func (h *GoalHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.GoalUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseDate reads an optional YYYY-MM-DD query parameter, defaulting to today.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
+
+func toGoal(req *GoalRequest) (*models.Goal, error) {
+	if ok, err := isRequestValid(req); !ok {
+		return nil, err
+	}
+
+	target, err := time.Parse("2006-01-02", req.TargetDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g := &models.Goal{
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		TargetDate:   target,
+		Currency:     req.Currency,
+	}
+
+	for _, id := range req.AccountIDs {
+		g.Accounts = append(g.Accounts, &models.Account{ID: id})
+	}
+
+	return g, nil
+}
+
+func isRequestValid(m *GoalRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrRateNotFound:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,20 @@
+package goal
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int) (res []*models.Goal, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Goal, err error)
+	// Store and Update save the goal together with its linked accounts.
+	Store(ctx context.Context, g *models.Goal) error
+	Update(ctx context.Context, g *models.Goal) error
+	Delete(ctx context.Context, userId int, id int) error
+	// FetchMonthlyTotals sums the active transactions of the given accounts dated on or before date by month and
+	// account currency. Each total comes back as a transaction dated on the first day of its month.
+	FetchMonthlyTotals(ctx context.Context, userId int, accountIds []int, date time.Time) ([]*models.Transaction, error)
+}
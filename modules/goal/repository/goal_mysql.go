@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/goal"
+	"github.com/sirupsen/logrus"
+)
+
+const selectGoal = `SELECT id, user_id, name, target_amount, target_date, currency, status, created_at, updated_at FROM goals`
+
+type mySqlGoalRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlGoalRepository(Conn *sql.DB) goal.Repository {
+	return &mySqlGoalRepository{Conn}
+}
+
+func (m *mySqlGoalRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Goal, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Goal, 0)
+
+	for rows.Next() {
+		g := new(models.Goal)
+		status := int(0)
+
+		err = rows.Scan(
+			&g.ID,
+			&g.UserID,
+			&g.Name,
+			&g.TargetAmount,
+			&g.TargetDate,
+			&g.Currency,
+			&status,
+			&g.CreatedAt,
+			&g.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if status == 1 {
+			g.Status = "active"
+		} else {
+			g.Status = "inactive"
+		}
+
+		g.Accounts = make([]*models.Account, 0)
+
+		result = append(result, g)
+	}
+
+	return result, nil
+}
+
+// fetchAccounts loads the linked accounts of every goal in list.
+func (m *mySqlGoalRepository) fetchAccounts(ctx context.Context, list []*models.Goal) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*models.Goal, len(list))
+	ids := make([]int, len(list))
+
+	for i, g := range list {
+		byId[g.ID] = g
+		ids[i] = g.ID
+	}
+
+	query := fmt.Sprintf(`SELECT ga.goal_id, a.id, a.name, a.type, a.description, a.currency, a.balance, a.status
+		FROM goal_accounts ga JOIN accounts a ON ga.account_id=a.id WHERE ga.goal_id IN (%s) ORDER BY a.name`, joinIds(ids))
+
+	rows, err := m.Conn.QueryContext(ctx, query)
+
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	for rows.Next() {
+		a := new(models.Account)
+		goalId := int(0)
+		status := int(0)
+
+		err = rows.Scan(
+			&goalId,
+			&a.ID,
+			&a.Name,
+			&a.Type,
+			&a.Description,
+			&a.Currency,
+			&a.Balance,
+			&status,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		if status == 1 {
+			a.Status = "active"
+		} else {
+			a.Status = "inactive"
+		}
+
+		g := byId[goalId]
+		g.Accounts = append(g.Accounts, a)
+	}
+
+	return nil
+}
+
+func joinIds(ids []int) string {
+	list := make([]string, len(ids))
+
+	for i, id := range ids {
+		list[i] = strconv.Itoa(id)
+	}
+
+	return strings.Join(list, ",")
+}
+
+func (m *mySqlGoalRepository) FetchAll(ctx context.Context, userId int) (res []*models.Goal, err error) {
+	query := selectGoal + ` WHERE status=1 AND user_id = ? ORDER BY target_date, id`
+
+	list, err := m.fetch(ctx, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.fetchAccounts(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (m *mySqlGoalRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Goal, err error) {
+	query := selectGoal + ` WHERE status=1 AND user_id = ? AND id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err = m.fetchAccounts(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (m *mySqlGoalRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// storeAccounts replaces the linked accounts of g with g.Accounts.
+func (m *mySqlGoalRepository) storeAccounts(ctx context.Context, tx *sql.Tx, g *models.Goal) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM goal_accounts WHERE goal_id = ?`, g.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, a := range g.Accounts {
+		_, err = tx.ExecContext(ctx, `INSERT goal_accounts SET goal_id=?, account_id=?`, g.ID, a.ID)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *mySqlGoalRepository) Store(ctx context.Context, g *models.Goal) error {
+	query := `INSERT goals SET user_id=?, name=?, target_amount=?, target_date=?, currency=?, created_at=?, updated_at=?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, g.UserID, g.Name, g.TargetAmount, g.TargetDate.Format("2006-01-02"), g.Currency, g.CreatedAt, g.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		g.ID = int(lastID)
+
+		return m.storeAccounts(ctx, tx, g)
+	})
+}
+
+func (m *mySqlGoalRepository) Update(ctx context.Context, g *models.Goal) error {
+	query := `UPDATE goals SET name=?, target_amount=?, target_date=?, currency=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, g.Name, g.TargetAmount, g.TargetDate.Format("2006-01-02"), g.Currency, g.UpdatedAt, g.UserID, g.ID)
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		if affect != 1 {
+			err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+			return err
+		}
+
+		return m.storeAccounts(ctx, tx, g)
+	})
+}
+
+func (m *mySqlGoalRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE goals SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
+
+func (m *mySqlGoalRepository) FetchMonthlyTotals(ctx context.Context, userId int, accountIds []int, date time.Time) ([]*models.Transaction, error) {
+	if len(accountIds) == 0 {
+		return make([]*models.Transaction, 0), nil
+	}
+
+	query := fmt.Sprintf(`SELECT SUM(t.amount_in), SUM(t.amount_out), year(t.trx_date) AS year, month(t.trx_date) AS month, a.currency
+		FROM transactions t JOIN accounts a ON t.account_id=a.id
+		WHERE t.status=1 AND t.user_id = ? AND t.trx_date <= ? AND t.account_id IN (%s)
+		GROUP BY year(t.trx_date), month(t.trx_date), a.currency`, joinIds(accountIds))
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId, date.Format("2006-01-02"))
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Transaction, 0)
+
+	for rows.Next() {
+		t := new(models.Transaction)
+		year, month := int(0), int(0)
+
+		err = rows.Scan(
+			&t.AmountIn,
+			&t.AmountOut,
+			&year,
+			&month,
+			&t.Account.Currency,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		t.Date = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
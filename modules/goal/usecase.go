@@ -0,0 +1,17 @@
+package goal
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// FetchAll and FetchById evaluate the progress of each goal on date.
+	FetchAll(c context.Context, userId int, date time.Time) ([]*models.Goal, error)
+	FetchById(c context.Context, userId int, id int, date time.Time) (*models.Goal, error)
+	Create(c context.Context, g *models.Goal) error
+	Update(c context.Context, g *models.Goal) (*models.Goal, error)
+	Delete(c context.Context, userId int, id int) error
+}
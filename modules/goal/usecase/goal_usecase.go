@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/exchangerate"
+	"github.com/arham09/fin-api/modules/goal"
+)
+
+// trailingMonths is how many complete months before the evaluated one set the contribution rate.
+const trailingMonths = 3
+
+type goalUsecase struct {
+	goalRepo       goal.Repository
+	accountRepo    account.Repository
+	rateRepo       exchangerate.Repository
+	contextTimeout time.Duration
+}
+
+func NewGoalUsecase(g goal.Repository, a account.Repository, r exchangerate.Repository, timeout time.Duration) goal.Usecase {
+	return &goalUsecase{
+		goalRepo:       g,
+		accountRepo:    a,
+		rateRepo:       r,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *goalUsecase) validate(ctx context.Context, g *models.Goal) error {
+	if g.Name == "" || g.TargetAmount <= 0 || g.TargetDate.IsZero() || len(g.Accounts) == 0 {
+		return helpers.ErrBadParamInput
+	}
+
+	if g.Currency == "" {
+		g.Currency = helpers.DefaultCurrency
+	}
+
+	g.Currency = strings.ToUpper(g.Currency)
+
+	if err := helpers.VerifyCurrency(g.Currency); err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool, len(g.Accounts))
+
+	for i, a := range g.Accounts {
+		if seen[a.ID] {
+			return helpers.ErrBadParamInput
+		}
+
+		seen[a.ID] = true
+
+		acc, err := u.accountRepo.FetchById(ctx, g.UserID, a.ID)
+
+		if err != nil {
+			return err
+		}
+
+		g.Accounts[i] = acc
+	}
+
+	return nil
+}
+
+// monthsLeft counts the months in which contributions can still be made from date until target, the month of
+// date included; the target month only counts when date is not later in the month than target.
+func monthsLeft(date time.Time, target time.Time) int {
+	months := (target.Year()-date.Year())*12 + int(target.Month()) - int(date.Month())
+
+	if target.Day() >= date.Day() {
+		months++
+	}
+
+	if months < 0 {
+		return 0
+	}
+
+	return months
+}
+
+// evaluate fills the progress of the goal on date from the monthly totals of its linked accounts, each converted
+// to the goal currency with the rate of its month.
+func (u *goalUsecase) evaluate(ctx context.Context, g *models.Goal, date time.Time) error {
+	accountIds := make([]int, len(g.Accounts))
+
+	for i, a := range g.Accounts {
+		accountIds[i] = a.ID
+	}
+
+	totals, err := u.goalRepo.FetchMonthlyTotals(ctx, g.UserID, accountIds, date)
+
+	if err != nil {
+		return err
+	}
+
+	converter := exchangerate.NewConverter(u.rateRepo, g.UserID)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	current := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	trailingStart := current.AddDate(0, -trailingMonths, 0)
+
+	var saved, trailing models.Money
+
+	for _, t := range totals {
+		net, err := converter.Convert(ctx, t.AmountIn-t.AmountOut, t.Account.Currency, g.Currency, t.Date)
+
+		if err != nil {
+			return err
+		}
+
+		saved += net
+
+		if !t.Date.Before(trailingStart) && t.Date.Before(current) {
+			trailing += net
+		}
+	}
+
+	p := &models.GoalProgress{
+		Date:            day,
+		Saved:           saved,
+		Remaining:       g.TargetAmount - saved,
+		Percent:         math.Round(saved.Float64()/g.TargetAmount.Float64()*10000) / 100,
+		MonthsLeft:      monthsLeft(day, g.TargetDate),
+		TrailingMonthly: trailing.DivRound(trailingMonths),
+	}
+
+	g.Progress = p
+
+	if p.Remaining <= 0 {
+		p.Remaining = 0
+		p.State = "achieved"
+
+		return nil
+	}
+
+	if p.MonthsLeft > 0 {
+		p.RequiredMonthly = p.Remaining.DivRound(int64(p.MonthsLeft))
+	} else {
+		p.RequiredMonthly = p.Remaining
+	}
+
+	if p.TrailingMonthly > 0 {
+		months := (int64(p.Remaining) + int64(p.TrailingMonthly) - 1) / int64(p.TrailingMonthly)
+		projected := day.AddDate(0, int(months), 0)
+		p.ProjectedDate = &projected
+	}
+
+	switch {
+	case p.MonthsLeft == 0:
+		p.State = "overdue"
+	case p.ProjectedDate != nil && !p.ProjectedDate.After(g.TargetDate):
+		p.State = "on_track"
+	default:
+		p.State = "behind"
+	}
+
+	return nil
+}
+
+func (u *goalUsecase) FetchAll(c context.Context, userId int, date time.Time) ([]*models.Goal, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.goalRepo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range res {
+		if err = u.evaluate(ctx, g, date); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (u *goalUsecase) FetchById(c context.Context, userId int, id int, date time.Time) (*models.Goal, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.goalRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.evaluate(ctx, res, date); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *goalUsecase) Create(c context.Context, g *models.Goal) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := u.validate(ctx, g); err != nil {
+		return err
+	}
+
+	g.Status = "active"
+	g.CreatedAt = time.Now()
+	g.UpdatedAt = time.Now()
+
+	err := u.goalRepo.Store(ctx, g)
+
+	if err != nil {
+		return err
+	}
+
+	return u.evaluate(ctx, g, time.Now())
+}
+
+func (u *goalUsecase) Update(c context.Context, g *models.Goal) (*models.Goal, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.goalRepo.FetchById(ctx, g.UserID, g.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validate(ctx, g); err != nil {
+		return nil, err
+	}
+
+	g.UpdatedAt = time.Now()
+
+	err = u.goalRepo.Update(ctx, g)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.goalRepo.FetchById(ctx, g.UserID, g.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.evaluate(ctx, res, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *goalUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.goalRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.goalRepo.Delete(ctx, userId, id)
+}
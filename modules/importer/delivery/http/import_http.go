@@ -0,0 +1,521 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/importer"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ProfileRequest struct {
+	Name              string `json:"name" validate:"required"`
+	Delimiter         string `json:"delimiter"`
+	SkipRows          int    `json:"skipRows"`
+	DateColumn        int    `json:"dateColumn" validate:"required"`
+	DateFormat        string `json:"dateFormat"`
+	DescriptionColumn int    `json:"descriptionColumn"`
+	AmountColumn      int    `json:"amountColumn"`
+	AmountSign        string `json:"amountSign" validate:"omitempty,oneof=negative-out negative-in"`
+	DebitColumn       int    `json:"debitColumn"`
+	CreditColumn      int    `json:"creditColumn"`
+	DecimalSeparator  string `json:"decimalSeparator"`
+}
+
+type ImportHandler struct {
+	ImportUsecase importer.Usecase
+}
+
+func NewImportHandler(e *echo.Echo, iu importer.Usecase, middleware *middleware.Middleware) {
+	handler := &ImportHandler{
+		ImportUsecase: iu,
+	}
+
+	e.GET("/v1/import/profile", handler.FetchAllProfiles, middleware.Authorize)
+	e.GET("/v1/import/profile/:id", handler.FetchProfileById, middleware.Authorize)
+	e.POST("/v1/import/profile", handler.CreateProfile, middleware.Authorize)
+	e.PATCH("/v1/import/profile/:id", handler.UpdateProfile, middleware.Authorize)
+	e.DELETE("/v1/import/profile/:id", handler.DeleteProfile, middleware.Authorize)
+	e.POST("/v1/account/:id/import/csv", handler.ImportCSV, middleware.Authorize)
+	e.POST("/v1/account/:id/import/ofx", handler.ImportOFX, middleware.Authorize)
+	e.POST("/v1/account/:id/import/qif", handler.ImportQIF, middleware.Authorize)
+	e.GET("/v1/account/:id/export/qif", handler.ExportQIF, middleware.Authorize)
+}
+
+// ShowImportProfile godoc
+// @Summary Show List Import Profile
+// @Description get every CSV import profile of the user
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.ImportProfile
+// @Header 200 {string} Token "qwerty"
+// @Router /import/profile [get]
+func (h *ImportHandler) FetchAllProfiles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.ImportUsecase.FetchAllProfiles(ctx, c.Get("userId").(int))
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowImportProfile godoc
+// @Summary Show an Import Profile
+// @Description get CSV import profile by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Import profile id"
+// @Success 200 {object} models.ImportProfile
+// @Header 200 {string} Token "qwerty"
+// @Router /import/profile/{id} [get]
+func (h *ImportHandler) FetchProfileById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.ImportUsecase.FetchProfileById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateImportProfile godoc
+// @Summary Create an Import Profile
+// @Description Create a CSV column mapping. Columns count from 1; use amountColumn with amountSign, or debitColumn and creditColumn. dateFormat is written like DD/MM/YYYY
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param profile body ProfileRequest true "ProfileRequest Body"
+// @Success 201 {object} models.ImportProfile
+// @Header 200 {string} Token "qwerty"
+// @Router /import/profile [post]
+func (h *ImportHandler) CreateProfile(c echo.Context) error {
+	var req ProfileRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	p := toProfile(&req)
+	p.UserID = c.Get("userId").(int)
+
+	err = h.ImportUsecase.CreateProfile(ctx, p)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, p)
+}
+
+// UpdateImportProfile godoc
+// @Summary Update Import Profile
+// @Description Update CSV import profile by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Import profile id"
+// @Param profile body ProfileRequest true "ProfileRequest Body"
+// @Success 200 {object} models.ImportProfile
+// @Header 200 {string} Token "qwerty"
+// @Router /import/profile/{id} [patch]
+func (h *ImportHandler) UpdateProfile(c echo.Context) error {
+	var req ProfileRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	p := toProfile(&req)
+	p.ID = id
+	p.UserID = c.Get("userId").(int)
+
+	res, err := h.ImportUsecase.UpdateProfile(ctx, p)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteImportProfile godoc
+// @Summary Delete Import Profile
+// @Description Delete CSV import profile by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Import profile id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /import/profile/{id} [delete]
+func (h *ImportHandler) DeleteProfile(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.ImportUsecase.DeleteProfile(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ImportCSV godoc
+// @Summary Import a CSV Statement
+// @Description Parse a bank statement with an import profile and return the parsed rows with a per-row error report. Nothing is stored unless commit is true, in which case every parsed row is inserted in one database transaction
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param profileId formData int true "Import profile id"
+// @Param file formData file true "CSV statement"
+// @Param commit query bool false "store the parsed rows instead of previewing them"
+// @Success 200 {object} models.ImportResult
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/import/csv [post]
+func (h *ImportHandler) ImportCSV(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	profileId, err := strconv.Atoi(c.FormValue("profileId"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "profileId is missing",
+		})
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	commit := c.QueryParam("commit") == "true"
+
+	res, err := h.ImportUsecase.ImportCSV(ctx, c.Get("userId").(int), accountId, profileId, src, commit)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ImportOFX godoc
+// @Summary Import an OFX Statement
+// @Description Parse an OFX 1.x (SGML) or 2.x (XML) bank statement, also accepted as QFX. Entries whose FITID was imported before are skipped, and the statement's ledger balance is compared with the account balance on the same day. Nothing is stored unless commit is true
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param file formData file true "OFX statement"
+// @Param commit query bool false "store the parsed entries instead of previewing them"
+// @Success 200 {object} models.ImportResult
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/import/ofx [post]
+func (h *ImportHandler) ImportOFX(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	commit := c.QueryParam("commit") == "true"
+
+	res, err := h.ImportUsecase.ImportOFX(ctx, c.Get("userId").(int), accountId, src, commit)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ImportQIF godoc
+// @Summary Import a QIF File
+// @Description Read the bank, cash and credit card sections of a QIF file, including split lines. Categories are matched by their full Parent:Child path and, on commit, created when missing. Nothing is stored unless commit is true
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param file formData file true "QIF file"
+// @Param dateOrder formData string false "mdy (default) or dmy, for dates that do not start with the year"
+// @Param commit query bool false "store the parsed entries instead of previewing them"
+// @Success 200 {object} models.ImportResult
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/import/qif [post]
+func (h *ImportHandler) ImportQIF(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	commit := c.QueryParam("commit") == "true"
+
+	res, err := h.ImportUsecase.ImportQIF(ctx, c.Get("userId").(int), accountId, src, c.FormValue("dateOrder"), commit)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ExportQIF godoc
+// @Summary Export a QIF File
+// @Description Download the transactions of an account dated within a range as a QIF file, with split lines and Parent:Child category paths
+// @Produce  application/qif
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param from query string true "first day of the range, YYYY-MM-DD"
+// @Param to query string true "last day of the range, YYYY-MM-DD"
+// @Success 200 {file} file
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/export/qif [get]
+func (h *ImportHandler) ExportQIF(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	from, err := time.Parse("2006-01-02", c.QueryParam("from"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "from must be a date formatted as YYYY-MM-DD",
+		})
+	}
+
+	to, err := time.Parse("2006-01-02", c.QueryParam("to"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "to must be a date formatted as YYYY-MM-DD",
+		})
+	}
+
+	var buf bytes.Buffer
+
+	err = h.ImportUsecase.ExportQIF(ctx, c.Get("userId").(int), accountId, from, to, &buf)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	filename := fmt.Sprintf("account-%d-%s-%s.qif", accountId, from.Format("20060102"), to.Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	return c.Blob(http.StatusOK, "application/qif", buf.Bytes())
+}
+
+func toProfile(req *ProfileRequest) *models.ImportProfile {
+	return &models.ImportProfile{
+		Name:              req.Name,
+		Delimiter:         req.Delimiter,
+		SkipRows:          req.SkipRows,
+		DateColumn:        req.DateColumn,
+		DateFormat:        req.DateFormat,
+		DescriptionColumn: req.DescriptionColumn,
+		AmountColumn:      req.AmountColumn,
+		AmountSign:        req.AmountSign,
+		DebitColumn:       req.DebitColumn,
+		CreditColumn:      req.CreditColumn,
+		DecimalSeparator:  req.DecimalSeparator,
+	}
+}
+
+func isRequestValid(m *ProfileRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrPeriodClosed:
+		return http.StatusLocked
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,226 @@
+// Package ofx reads bank and credit card statements in OFX 1.x (SGML) and 2.x (XML) format.
+package ofx
+
+import (
+	"errors"
+	"html"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// ErrNoStatement is returned for files without an <OFX> element.
+var ErrNoStatement = errors.New("ofx: file has no OFX element")
+
+// Entry is one STMTTRN of a statement. Amount is negative for money going out.
+type Entry struct {
+	FITID   string
+	Type    string
+	Posted  time.Time
+	Amount  models.Money
+	Name    string
+	Memo    string
+	CheckNo string
+}
+
+// Statement holds the transactions of every statement in a file, with the ledger balance of the last one.
+type Statement struct {
+	Currency      string
+	AccountID     string
+	Entries       []*Entry
+	LedgerBalance *models.Money
+	LedgerDate    time.Time
+}
+
+// Parse reads an OFX file. SGML files leave the closing tags of leaf elements out, so both versions are read
+// with the same tag scanner: a tag followed by text is a leaf, any other opening tag starts an aggregate that
+// lasts until its closing tag.
+func Parse(r io.Reader) (*Statement, error) {
+	data, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := string(data)
+	start := strings.Index(strings.ToUpper(body), "<OFX>")
+
+	if start < 0 {
+		return nil, ErrNoStatement
+	}
+
+	body = body[start:]
+
+	st := &Statement{}
+	stack := make([]string, 0)
+
+	var entry *Entry
+	var ledger map[string]string
+
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+
+		if open < 0 {
+			break
+		}
+
+		end := strings.IndexByte(body[open:], '>')
+
+		if end < 0 {
+			return nil, errors.New("ofx: unterminated tag")
+		}
+
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		text := body
+
+		if next := strings.IndexByte(body, '<'); next >= 0 {
+			text = body[:next]
+		}
+
+		value := strings.TrimSpace(html.UnescapeString(text))
+
+		switch {
+		case strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+			// XML declarations, processing instructions and comments.
+		case strings.HasPrefix(tag, "/"):
+			name := tag[1:]
+
+			// Closing tags of leaves, which only XML files have, never match an open aggregate.
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i] != name {
+					continue
+				}
+
+				for _, closed := range stack[i:] {
+					switch closed {
+					case "STMTTRN":
+						if entry != nil {
+							st.Entries = append(st.Entries, entry)
+							entry = nil
+						}
+					case "LEDGERBAL":
+						if err := st.setLedger(ledger); err != nil {
+							return nil, err
+						}
+
+						ledger = nil
+					}
+				}
+
+				stack = stack[:i]
+
+				break
+			}
+		case value == "":
+			stack = append(stack, tag)
+
+			switch tag {
+			case "STMTTRN":
+				entry = &Entry{}
+			case "LEDGERBAL":
+				ledger = make(map[string]string)
+			}
+		default:
+			parent := ""
+
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+
+			switch {
+			case parent == "STMTTRN" && entry != nil:
+				if err := entry.set(tag, value); err != nil {
+					return nil, err
+				}
+			case parent == "LEDGERBAL" && ledger != nil:
+				ledger[tag] = value
+			case tag == "CURDEF":
+				st.Currency = strings.ToUpper(value)
+			case tag == "ACCTID":
+				st.AccountID = value
+			}
+		}
+	}
+
+	return st, nil
+}
+
+func (e *Entry) set(tag string, value string) error {
+	var err error
+
+	switch tag {
+	case "FITID":
+		e.FITID = value
+	case "TRNTYPE":
+		e.Type = value
+	case "DTPOSTED":
+		e.Posted, err = parseDate(value)
+	case "TRNAMT":
+		e.Amount, err = parseAmount(value)
+	case "NAME":
+		e.Name = value
+	case "MEMO":
+		e.Memo = value
+	case "CHECKNUM":
+		e.CheckNo = value
+	}
+
+	return err
+}
+
+func (st *Statement) setLedger(fields map[string]string) error {
+	amount, err := parseAmount(fields["BALAMT"])
+
+	if err != nil {
+		return err
+	}
+
+	date, err := parseDate(fields["DTASOF"])
+
+	if err != nil {
+		return err
+	}
+
+	st.LedgerBalance = &amount
+	st.LedgerDate = date
+
+	return nil
+}
+
+// parseDate reads the day of an OFX datetime such as 20260131120000.000[+7:WIB]. The statement lists the day
+// the bank posted each transaction, so the time and zone are dropped.
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("ofx: invalid date " + value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+
+	if err != nil {
+		return time.Time{}, errors.New("ofx: invalid date " + value)
+	}
+
+	return date, nil
+}
+
+// parseAmount reads a signed amount; some banks write the decimal point as a comma.
+func parseAmount(value string) (models.Money, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	amount, err := models.ParseMoney(value)
+
+	if err != nil {
+		return 0, errors.New("ofx: invalid amount " + value)
+	}
+
+	return amount, nil
+}
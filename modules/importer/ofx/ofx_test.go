@@ -0,0 +1,158 @@
+package ofx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>idr
+<BANKACCTFROM>
+<ACCTID>1234567890
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260131120000.000[+7:WIB]
+<TRNAMT>-150000,50
+<FITID>A1
+<NAME>Grocer &amp; Co
+<MEMO>weekly
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CHECK
+<DTPOSTED>20260201
+<TRNAMT>2500.00
+<FITID>A2
+<NAME>Salary
+<CHECKNUM>1001
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>1000000.25
+<DTASOF>20260201
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="211"?>
+<OFX>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <STMTRS>
+        <CURDEF>IDR</CURDEF>
+        <BANKACCTFROM>
+          <ACCTID>1234567890</ACCTID>
+        </BANKACCTFROM>
+        <BANKTRANLIST>
+          <!-- two entries -->
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20260131120000.000[+7:WIB]</DTPOSTED>
+            <TRNAMT>-150000.50</TRNAMT>
+            <FITID>A1</FITID>
+            <NAME>Grocer &amp; Co</NAME>
+            <MEMO>weekly</MEMO>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>CHECK</TRNTYPE>
+            <DTPOSTED>20260201</DTPOSTED>
+            <TRNAMT>2500.00</TRNAMT>
+            <FITID>A2</FITID>
+            <NAME>Salary</NAME>
+            <CHECKNUM>1001</CHECKNUM>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>1000000.25</BALAMT>
+          <DTASOF>20260201</DTASOF>
+        </LEDGERBAL>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+</OFX>
+`
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	balance := models.Money(10000002500)
+
+	want := &Statement{
+		Currency:  "IDR",
+		AccountID: "1234567890",
+		Entries: []*Entry{
+			{FITID: "A1", Type: "DEBIT", Posted: date(2026, 1, 31), Amount: -1500005000, Name: "Grocer & Co", Memo: "weekly"},
+			{FITID: "A2", Type: "CHECK", Posted: date(2026, 2, 1), Amount: 25000000, Name: "Salary", CheckNo: "1001"},
+		},
+		LedgerBalance: &balance,
+		LedgerDate:    date(2026, 2, 1),
+	}
+
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "sgml", in: sgmlStatement},
+		{name: "xml", in: xmlStatement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.in))
+
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse() = %+v, want %+v", got, want)
+
+				for i, e := range got.Entries {
+					t.Logf("entry %d: %+v", i, e)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{name: "no ofx element", in: "<HTML></HTML>", err: ErrNoStatement.Error()},
+		{name: "unterminated tag", in: "<OFX><STMTTRN", err: "ofx: unterminated tag"},
+		{name: "bad date", in: "<OFX><STMTTRN><DTPOSTED>2026</STMTTRN></OFX>", err: "ofx: invalid date 2026"},
+		{name: "bad amount", in: "<OFX><STMTTRN><TRNAMT>1.2.3</STMTTRN></OFX>", err: "ofx: invalid amount 1.2.3"},
+		{name: "too many decimals", in: "<OFX><STMTTRN><TRNAMT>1.23456</STMTTRN></OFX>", err: "ofx: invalid amount 1.23456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.in))
+
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Parse() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
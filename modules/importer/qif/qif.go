@@ -0,0 +1,278 @@
+// Package qif reads and writes the bank, cash and credit card sections of Quicken Interchange Format files.
+package qif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Split is an S/E/$ line of an entry. Amount has the sign of the money moving, like the entry amount.
+type Split struct {
+	Category string
+	Memo     string
+	Amount   models.Money
+}
+
+// Entry is one record of a bank, cash or credit card section. Category holds the L field without its class;
+// transfers to other accounts keep their [Account] form.
+type Entry struct {
+	Line     int
+	Date     time.Time
+	Amount   models.Money
+	Payee    string
+	Memo     string
+	Category string
+	CheckNo  string
+	Cleared  string
+	Splits   []*Split
+}
+
+// sections lists the account types whose records are transactions of a single account.
+var sections = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+}
+
+// Read returns the entries of every bank, cash and credit card section of a QIF file, skipping other sections
+// such as category lists and investment accounts. dateOrder is "mdy" or "dmy" and tells how to read dates
+// that do not start with a four digit year. Records that cannot be read are reported with the line they start on.
+func Read(r io.Reader, dateOrder string) ([]*Entry, []*models.ImportError) {
+	scanner := bufio.NewScanner(r)
+	entries := make([]*Entry, 0)
+	rowErrors := make([]*models.ImportError, 0)
+
+	inSection := false
+	line := 0
+
+	var entry *Entry
+	var split *Split
+	var err error
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			inSection = strings.HasPrefix(header, "!type:") && sections[strings.TrimPrefix(header, "!type:")]
+			entry, split, err = nil, nil, nil
+			continue
+		}
+
+		if !inSection {
+			continue
+		}
+
+		if entry == nil {
+			entry = &Entry{Line: line}
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+		var fieldErr error
+
+		switch code {
+		case '^':
+			if err == nil && entry.Date.IsZero() {
+				err = fmt.Errorf("record has no date")
+			}
+
+			if err != nil {
+				rowErrors = append(rowErrors, &models.ImportError{Row: entry.Line, Message: err.Error()})
+			} else {
+				entries = append(entries, entry)
+			}
+
+			entry, split, err = nil, nil, nil
+			continue
+		case 'D':
+			entry.Date, fieldErr = parseDate(value, dateOrder)
+		case 'T', 'U':
+			entry.Amount, fieldErr = parseAmount(value)
+		case 'P':
+			entry.Payee = value
+		case 'M':
+			entry.Memo = value
+		case 'L':
+			entry.Category = stripClass(value)
+		case 'N':
+			entry.CheckNo = value
+		case 'C':
+			entry.Cleared = value
+		case 'S':
+			split = &Split{Category: stripClass(value)}
+			entry.Splits = append(entry.Splits, split)
+		case 'E':
+			if split != nil {
+				split.Memo = value
+			}
+		case '$':
+			if split != nil {
+				split.Amount, fieldErr = parseAmount(value)
+			}
+		}
+
+		// A record reports the first field that could not be read.
+		if err == nil {
+			err = fieldErr
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		rowErrors = append(rowErrors, &models.ImportError{Row: line, Message: err.Error()})
+	}
+
+	return entries, rowErrors
+}
+
+// stripClass drops the "/class" part of a category field.
+func stripClass(value string) string {
+	if i := strings.IndexByte(value, '/'); i >= 0 && !strings.HasPrefix(value, "[") {
+		return value[:i]
+	}
+
+	return value
+}
+
+func parseAmount(value string) (models.Money, error) {
+	amount, err := models.ParseMoney(strings.Replace(value, ",", "", -1))
+
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", value)
+	}
+
+	return amount, nil
+}
+
+// parseDate reads the date styles written by desktop finance tools: 1/31/2026, 01/31/26, 1/31'26, 31.01.2026
+// and 2026-01-31. Two digit years are read as 19xx from 70 up and as 20xx below.
+func parseDate(value string, dateOrder string) (time.Time, error) {
+	normalized := strings.NewReplacer("'", "/", "-", "/", ".", "/", " ", "").Replace(value)
+	parts := strings.Split(normalized, "/")
+
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("date %q is not recognized", value)
+	}
+
+	numbers := make([]int, 3)
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("date %q is not recognized", value)
+		}
+
+		numbers[i] = n
+	}
+
+	var year, month, day int
+
+	switch {
+	case len(parts[0]) == 4:
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	case dateOrder == "dmy":
+		day, month, year = numbers[0], numbers[1], numbers[2]
+	default:
+		month, day, year = numbers[0], numbers[1], numbers[2]
+	}
+
+	if len(parts[2]) <= 2 && len(parts[0]) != 4 {
+		if year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("date %q is not recognized", value)
+	}
+
+	return date, nil
+}
+
+// Writer writes entries as one QIF section.
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter starts a section for accountType, which is Bank, Cash or CCard.
+func NewWriter(w io.Writer, accountType string) (*Writer, error) {
+	qw := &Writer{bufio.NewWriter(w)}
+
+	if _, err := fmt.Fprintf(qw.w, "!Type:%s\n", accountType); err != nil {
+		return nil, err
+	}
+
+	return qw, nil
+}
+
+func (qw *Writer) Write(e *Entry) error {
+	fields := []string{
+		"D" + e.Date.Format("01/02/2006"),
+		"T" + e.Amount.String(),
+	}
+
+	if e.Cleared != "" {
+		fields = append(fields, "C"+e.Cleared)
+	}
+
+	if e.CheckNo != "" {
+		fields = append(fields, "N"+e.CheckNo)
+	}
+
+	if e.Payee != "" {
+		fields = append(fields, "P"+oneLine(e.Payee))
+	}
+
+	if e.Memo != "" {
+		fields = append(fields, "M"+oneLine(e.Memo))
+	}
+
+	if e.Category != "" {
+		fields = append(fields, "L"+e.Category)
+	}
+
+	for _, s := range e.Splits {
+		fields = append(fields, "S"+s.Category)
+
+		if s.Memo != "" {
+			fields = append(fields, "E"+oneLine(s.Memo))
+		}
+
+		fields = append(fields, "$"+s.Amount.String())
+	}
+
+	fields = append(fields, "^")
+
+	_, err := qw.w.WriteString(strings.Join(fields, "\n") + "\n")
+
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (qw *Writer) Flush() error {
+	return qw.w.Flush()
+}
+
+// oneLine keeps multi-line text from breaking the line based format.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
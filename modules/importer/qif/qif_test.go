@@ -0,0 +1,206 @@
+package qif
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in    string
+		order string
+		want  time.Time
+		err   bool
+	}{
+		{in: "1/31/2026", order: "mdy", want: date(2026, 1, 31)},
+		{in: "01/31/26", order: "mdy", want: date(2026, 1, 31)},
+		{in: "1/31'26", order: "mdy", want: date(2026, 1, 31)},
+		{in: "1/31' 6", order: "mdy", want: date(2006, 1, 31)},
+		{in: "12/25/99", order: "mdy", want: date(1999, 12, 25)},
+		{in: "12/25/69", order: "mdy", want: date(2069, 12, 25)},
+		{in: "31.01.2026", order: "dmy", want: date(2026, 1, 31)},
+		{in: "31/01/26", order: "dmy", want: date(2026, 1, 31)},
+		{in: "2026-01-31", order: "dmy", want: date(2026, 1, 31)},
+		{in: "2026-01-31", order: "mdy", want: date(2026, 1, 31)},
+		{in: "2/29/2024", order: "mdy", want: date(2024, 2, 29)},
+		{in: "2/29/2025", order: "mdy", err: true},
+		{in: "31/01/2026", order: "mdy", err: true},
+		{in: "1/31", order: "mdy", err: true},
+		{in: "Jan 31 2026", order: "mdy", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDate(tt.in, tt.order)
+
+		if (err != nil) != tt.err {
+			t.Errorf("parseDate(%q, %q) error = %v, want error %v", tt.in, tt.order, err, tt.err)
+			continue
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q, %q) = %s, want %s", tt.in, tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		entries []*Entry
+		errors  []*models.ImportError
+	}{
+		{
+			name: "split entry",
+			in: "!Type:Bank\n" +
+				"D01/31/2026\n" +
+				"T-1,250.00\n" +
+				"PGrocer\n" +
+				"MWeekly shop\n" +
+				"L[Savings]\n" +
+				"N101\n" +
+				"CX\n" +
+				"SFood/Home\n" +
+				"EFruit\n" +
+				"$-1000.00\n" +
+				"SHousehold\n" +
+				"$-250.00\n" +
+				"^\n",
+			entries: []*Entry{{
+				Line:     2,
+				Date:     date(2026, 1, 31),
+				Amount:   -12500000,
+				Payee:    "Grocer",
+				Memo:     "Weekly shop",
+				Category: "[Savings]",
+				CheckNo:  "101",
+				Cleared:  "X",
+				Splits: []*Split{
+					{Category: "Food", Memo: "Fruit", Amount: -10000000},
+					{Category: "Household", Amount: -2500000},
+				},
+			}},
+			errors: []*models.ImportError{},
+		},
+		{
+			name: "skips other sections",
+			in: "\ufeff!Type:Cat\n" +
+				"NFood\n" +
+				"^\n" +
+				"!Type:Invst\n" +
+				"D1/2/2026\n" +
+				"T10\n" +
+				"^\n" +
+				"!Type:CCard\r\n" +
+				"D2/1/2026\r\n" +
+				"U20.5\r\n" +
+				"LFuel/Car\r\n" +
+				"^\r\n",
+			entries: []*Entry{{
+				Line:     9,
+				Date:     date(2026, 2, 1),
+				Amount:   205000,
+				Category: "Fuel",
+			}},
+			errors: []*models.ImportError{},
+		},
+		{
+			name: "reports bad records by line",
+			in: "!Type:Cash\n" +
+				"Tabc\n" +
+				"D1/1/2026\n" +
+				"^\n" +
+				"T5\n" +
+				"^\n" +
+				"D13/1/2026\n" +
+				"T5\n" +
+				"^\n" +
+				"D1/2/2026\n" +
+				"T5\n" +
+				"^\n",
+			entries: []*Entry{{
+				Line:   10,
+				Date:   date(2026, 1, 2),
+				Amount: 50000,
+			}},
+			errors: []*models.ImportError{
+				{Row: 2, Message: `amount "abc" is not a number`},
+				{Row: 5, Message: "record has no date"},
+				{Row: 7, Message: `date "13/1/2026" is not recognized`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, errors := Read(strings.NewReader(tt.in), "mdy")
+
+			if !reflect.DeepEqual(entries, tt.entries) {
+				for _, e := range entries {
+					t.Logf("entry: %+v", e)
+				}
+
+				t.Errorf("Read() entries differ from %+v", tt.entries)
+			}
+
+			if !reflect.DeepEqual(errors, tt.errors) {
+				for _, e := range errors {
+					t.Logf("error: %+v", e)
+				}
+
+				t.Errorf("Read() errors differ from %+v", tt.errors)
+			}
+		})
+	}
+}
+
+func TestWriteReadsBack(t *testing.T) {
+	in := &Entry{
+		Line:     2,
+		Date:     date(2026, 3, 15),
+		Amount:   -7500000,
+		Payee:    "Hardware\nstore",
+		Category: "Home",
+		Splits: []*Split{
+			{Category: "Home", Memo: "Paint", Amount: -5000000},
+			{Category: "Tools", Amount: -2500000},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, "Bank")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = w.Write(in); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, errors := Read(&buf, "mdy")
+
+	if len(errors) != 0 || len(entries) != 1 {
+		t.Fatalf("Read() = %v, %v", entries, errors)
+	}
+
+	in.Payee = "Hardware store"
+
+	if !reflect.DeepEqual(entries[0], in) {
+		t.Errorf("Read() = %+v, want %+v", entries[0], in)
+	}
+}
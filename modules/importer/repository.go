@@ -0,0 +1,17 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAllProfiles(ctx context.Context, userId int) (res []*models.ImportProfile, err error)
+	FetchProfileById(ctx context.Context, userId int, id int) (res *models.ImportProfile, err error)
+	StoreProfile(ctx context.Context, p *models.ImportProfile) error
+	UpdateProfile(ctx context.Context, p *models.ImportProfile) error
+	DeleteProfile(ctx context.Context, userId int, id int) error
+	// FetchFitIDs returns which of the given FITIDs were already imported into the account.
+	FetchFitIDs(ctx context.Context, accountId int, fitIds []string) (map[string]bool, error)
+}
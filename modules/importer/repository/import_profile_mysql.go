@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/importer"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlImportRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlImportRepository(Conn *sql.DB) importer.Repository {
+	return &mySqlImportRepository{Conn}
+}
+
+func (m *mySqlImportRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.ImportProfile, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.ImportProfile, 0)
+
+	for rows.Next() {
+		p := new(models.ImportProfile)
+		status := int(0)
+
+		err = rows.Scan(
+			&p.ID,
+			&p.UserID,
+			&p.Name,
+			&p.Delimiter,
+			&p.SkipRows,
+			&p.DateColumn,
+			&p.DateFormat,
+			&p.DescriptionColumn,
+			&p.AmountColumn,
+			&p.AmountSign,
+			&p.DebitColumn,
+			&p.CreditColumn,
+			&p.DecimalSeparator,
+			&status,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if status == 1 {
+			p.Status = "active"
+		} else {
+			p.Status = "inactive"
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlImportRepository) FetchAllProfiles(ctx context.Context, userId int) (res []*models.ImportProfile, err error) {
+	query := `SELECT id, user_id, name, delimiter, skip_rows, date_column, date_format, description_column, amount_column, amount_sign, debit_column, credit_column, decimal_separator, status, created_at, updated_at
+		FROM import_profiles WHERE status=1 AND user_id = ? ORDER BY name`
+
+	return m.fetch(ctx, query, userId)
+}
+
+func (m *mySqlImportRepository) FetchProfileById(ctx context.Context, userId int, id int) (res *models.ImportProfile, err error) {
+	query := `SELECT id, user_id, name, delimiter, skip_rows, date_column, date_format, description_column, amount_column, amount_sign, debit_column, credit_column, decimal_separator, status, created_at, updated_at
+		FROM import_profiles WHERE status=1 AND user_id = ? AND id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlImportRepository) StoreProfile(ctx context.Context, p *models.ImportProfile) error {
+	query := `INSERT import_profiles SET user_id=?, name=?, delimiter=?, skip_rows=?, date_column=?, date_format=?, description_column=?, amount_column=?, amount_sign=?, debit_column=?, credit_column=?, decimal_separator=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, p.UserID, p.Name, p.Delimiter, p.SkipRows, p.DateColumn, p.DateFormat, p.DescriptionColumn, p.AmountColumn, p.AmountSign, p.DebitColumn, p.CreditColumn, p.DecimalSeparator, p.CreatedAt, p.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	p.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlImportRepository) UpdateProfile(ctx context.Context, p *models.ImportProfile) error {
+	query := `UPDATE import_profiles SET name=?, delimiter=?, skip_rows=?, date_column=?, date_format=?, description_column=?, amount_column=?, amount_sign=?, debit_column=?, credit_column=?, decimal_separator=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, p.Name, p.Delimiter, p.SkipRows, p.DateColumn, p.DateFormat, p.DescriptionColumn, p.AmountColumn, p.AmountSign, p.DebitColumn, p.CreditColumn, p.DecimalSeparator, p.UpdatedAt, p.UserID, p.ID)
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlImportRepository) DeleteProfile(ctx context.Context, userId int, id int) error {
+	query := `UPDATE import_profiles SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
+
+func (m *mySqlImportRepository) FetchFitIDs(ctx context.Context, accountId int, fitIds []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+
+	if len(fitIds) == 0 {
+		return result, nil
+	}
+
+	args := make([]interface{}, 0, len(fitIds)+1)
+	args = append(args, accountId)
+
+	for _, id := range fitIds {
+		args = append(args, id)
+	}
+
+	query := `SELECT fitid FROM transactions WHERE account_id = ? AND fitid IN (?` + strings.Repeat(",?", len(fitIds)-1) + `)`
+
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	for rows.Next() {
+		var id string
+
+		if err = rows.Scan(&id); err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result[id] = true
+	}
+
+	return result, nil
+}
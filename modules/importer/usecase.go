@@ -0,0 +1,28 @@
+package importer
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAllProfiles(c context.Context, userId int) ([]*models.ImportProfile, error)
+	FetchProfileById(c context.Context, userId int, id int) (*models.ImportProfile, error)
+	CreateProfile(c context.Context, p *models.ImportProfile) error
+	UpdateProfile(c context.Context, p *models.ImportProfile) (*models.ImportProfile, error)
+	DeleteProfile(c context.Context, userId int, id int) error
+	// ImportCSV parses a CSV statement for the account with a saved profile. The parsed transactions are only
+	// stored, all in one database transaction, when commit is set; otherwise the result is a preview.
+	ImportCSV(c context.Context, userId int, accountId int, profileId int, r io.Reader, commit bool) (*models.ImportResult, error)
+	// ImportOFX reads an OFX 1.x or 2.x statement for the account, skipping entries whose FITID was imported
+	// before, and compares the statement's ledger balance with ours. Like ImportCSV it only stores on commit.
+	ImportOFX(c context.Context, userId int, accountId int, r io.Reader, commit bool) (*models.ImportResult, error)
+	// ImportQIF reads the bank, cash and credit card sections of a QIF file for the account. dateOrder is "mdy"
+	// or "dmy" and only matters for dates that do not start with the year. Like ImportCSV it only stores on commit.
+	ImportQIF(c context.Context, userId int, accountId int, r io.Reader, dateOrder string, commit bool) (*models.ImportResult, error)
+	// ExportQIF writes the transactions of the account dated from from to to, both inclusive, as a QIF file.
+	ExportQIF(c context.Context, userId int, accountId int, from time.Time, to time.Time, w io.Writer) error
+}
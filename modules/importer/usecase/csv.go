@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// layout turns a date format written with YYYY, YY, MM, M, DD and D into a Go time layout. Formats that are
+// already Go layouts, such as 02/01/2006, are returned as they are.
+func layout(format string) string {
+	if strings.ContainsAny(format, "0123456789") {
+		return format
+	}
+
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "M", "1", "DD", "02", "D", "2")
+
+	return replacer.Replace(format)
+}
+
+// parseAmount reads an amount as printed on a statement: thousands separators, currency symbols and spaces are
+// ignored, and "(12.50)" or "12.50-" are negative. An empty cell is zero.
+func parseAmount(value string, decimalSeparator string) (models.Money, error) {
+	var b strings.Builder
+
+	negative := false
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	if strings.HasSuffix(value, "-") {
+		negative = true
+		value = strings.TrimSuffix(value, "-")
+	}
+
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+':
+			b.WriteRune(r)
+		case string(r) == decimalSeparator:
+			b.WriteRune('.')
+		}
+	}
+
+	if b.Len() == 0 {
+		return 0, nil
+	}
+
+	amount, err := models.ParseMoney(b.String())
+
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", value)
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+func column(record []string, index int) (string, error) {
+	if index > len(record) {
+		return "", fmt.Errorf("column %d is missing", index)
+	}
+
+	return strings.TrimSpace(record[index-1]), nil
+}
+
+// parseRecord maps one statement row to a transaction. A positive net amount is money coming in.
+func parseRecord(p *models.ImportProfile, record []string) (*models.Transaction, error) {
+	value, err := column(record, p.DateColumn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(layout(p.DateFormat), value)
+
+	if err != nil {
+		return nil, fmt.Errorf("date %q does not match %s", value, p.DateFormat)
+	}
+
+	var amount models.Money
+
+	if p.AmountColumn > 0 {
+		if value, err = column(record, p.AmountColumn); err != nil {
+			return nil, err
+		}
+
+		if amount, err = parseAmount(value, p.DecimalSeparator); err != nil {
+			return nil, err
+		}
+
+		if p.AmountSign == "negative-in" {
+			amount = -amount
+		}
+	} else {
+		for _, c := range []int{p.CreditColumn, p.DebitColumn} {
+			if value, err = column(record, c); err != nil {
+				return nil, err
+			}
+
+			part, err := parseAmount(value, p.DecimalSeparator)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if part < 0 {
+				part = -part
+			}
+
+			if c == p.DebitColumn {
+				part = -part
+			}
+
+			amount += part
+		}
+	}
+
+	if amount == 0 {
+		return nil, fmt.Errorf("amount is zero")
+	}
+
+	trx := &models.Transaction{Date: date}
+
+	if p.DescriptionColumn > 0 {
+		if trx.Description, err = column(record, p.DescriptionColumn); err != nil {
+			return nil, err
+		}
+	}
+
+	trx.Name = truncate(trx.Description, 55)
+
+	if trx.Name == "" {
+		trx.Name = "Imported transaction"
+	}
+
+	if amount > 0 {
+		trx.Type = "in"
+		trx.AmountIn = amount
+	} else {
+		trx.Type = "out"
+		trx.AmountOut = -amount
+	}
+
+	return trx, nil
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	return string([]rune(s)[:max])
+}
+
+// parseCSV reads a statement with the profile. Rows that cannot be read are reported by their line in the file
+// instead of failing the whole statement.
+func parseCSV(p *models.ImportProfile, r io.Reader) ([]*models.Transaction, []*models.ImportError) {
+	reader := csv.NewReader(r)
+	reader.Comma, _ = utf8.DecodeRuneInString(p.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	list := make([]*models.Transaction, 0)
+	rowErrors := make([]*models.ImportError, 0)
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if row <= p.SkipRows {
+			continue
+		}
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		trx, err := parseRecord(p, record)
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		list = append(list, trx)
+	}
+
+	return list, rowErrors
+}
@@ -0,0 +1,460 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/balance"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/arham09/fin-api/modules/importer"
+	"github.com/arham09/fin-api/modules/importer/ofx"
+	"github.com/arham09/fin-api/modules/importer/qif"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+type importUsecase struct {
+	importRepo     importer.Repository
+	accountRepo    account.Repository
+	trxRepo        transaction.Repository
+	balanceRepo    balance.Repository
+	categoryRepo   category.Repository
+	contextTimeout time.Duration
+}
+
+func NewImportUsecase(i importer.Repository, a account.Repository, t transaction.Repository, b balance.Repository, c category.Repository, timeout time.Duration) importer.Usecase {
+	return &importUsecase{
+		importRepo:     i,
+		accountRepo:    a,
+		trxRepo:        t,
+		balanceRepo:    b,
+		categoryRepo:   c,
+		contextTimeout: timeout,
+	}
+}
+
+// validate fills in the defaults of a profile and checks that it names a date column and either an amount
+// column or both a debit and a credit column.
+func validate(p *models.ImportProfile) error {
+	if p.Delimiter == "" {
+		p.Delimiter = ","
+	}
+
+	if p.DateFormat == "" {
+		p.DateFormat = "YYYY-MM-DD"
+	}
+
+	if p.AmountSign == "" {
+		p.AmountSign = "negative-out"
+	}
+
+	if p.DecimalSeparator == "" {
+		p.DecimalSeparator = "."
+	}
+
+	if p.Name == "" || utf8.RuneCountInString(p.Delimiter) != 1 || p.SkipRows < 0 || p.DateColumn < 1 {
+		return helpers.ErrBadParamInput
+	}
+
+	if p.DescriptionColumn < 0 || p.AmountColumn < 0 || p.DebitColumn < 0 || p.CreditColumn < 0 {
+		return helpers.ErrBadParamInput
+	}
+
+	if p.AmountColumn == 0 && (p.DebitColumn == 0 || p.CreditColumn == 0) {
+		return helpers.ErrBadParamInput
+	}
+
+	if p.AmountSign != "negative-out" && p.AmountSign != "negative-in" {
+		return helpers.ErrBadParamInput
+	}
+
+	if p.DecimalSeparator != "." && p.DecimalSeparator != "," {
+		return helpers.ErrBadParamInput
+	}
+
+	return nil
+}
+
+func (u *importUsecase) FetchAllProfiles(c context.Context, userId int) ([]*models.ImportProfile, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.importRepo.FetchAllProfiles(ctx, userId)
+}
+
+func (u *importUsecase) FetchProfileById(c context.Context, userId int, id int) (*models.ImportProfile, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.importRepo.FetchProfileById(ctx, userId, id)
+}
+
+func (u *importUsecase) CreateProfile(c context.Context, p *models.ImportProfile) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := validate(p); err != nil {
+		return err
+	}
+
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = time.Now()
+
+	return u.importRepo.StoreProfile(ctx, p)
+}
+
+func (u *importUsecase) UpdateProfile(c context.Context, p *models.ImportProfile) (*models.ImportProfile, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.importRepo.FetchProfileById(ctx, p.UserID, p.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validate(p); err != nil {
+		return nil, err
+	}
+
+	p.UpdatedAt = time.Now()
+
+	err = u.importRepo.UpdateProfile(ctx, p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.importRepo.FetchProfileById(ctx, p.UserID, p.ID)
+}
+
+func (u *importUsecase) DeleteProfile(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.importRepo.FetchProfileById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.importRepo.DeleteProfile(ctx, userId, id)
+}
+
+func (u *importUsecase) ImportCSV(c context.Context, userId int, accountId int, profileId int, r io.Reader, commit bool) (*models.ImportResult, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	acc, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := u.importRepo.FetchProfileById(ctx, userId, profileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, rowErrors := parseCSV(profile, r)
+	now := time.Now()
+
+	for _, trx := range list {
+		trx.UserID = userId
+		trx.Account = *acc
+		trx.CreatedAt = now
+		trx.UpdatedAt = now
+	}
+
+	res := &models.ImportResult{
+		Transactions: list,
+		Errors:       rowErrors,
+	}
+
+	if !commit || len(list) == 0 {
+		return res, nil
+	}
+
+	err = u.trxRepo.StoreBatch(ctx, list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Imported = len(list)
+	res.Committed = true
+
+	return res, nil
+}
+
+func (u *importUsecase) ImportOFX(c context.Context, userId int, accountId int, r io.Reader, commit bool) (*models.ImportResult, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	acc, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := ofx.Parse(r)
+
+	// A file that cannot be read as a whole is reported like a rejected row rather than as a server error.
+	if err != nil {
+		return &models.ImportResult{
+			Transactions: make([]*models.Transaction, 0),
+			Errors:       []*models.ImportError{{Message: err.Error()}},
+		}, nil
+	}
+
+	if st.Currency != "" && st.Currency != acc.Currency {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	fitIds := make([]string, 0, len(st.Entries))
+
+	for _, e := range st.Entries {
+		fitIds = append(fitIds, e.FITID)
+	}
+
+	imported, err := u.importRepo.FetchFitIDs(ctx, accountId, fitIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ImportResult{
+		Transactions: make([]*models.Transaction, 0),
+		Errors:       make([]*models.ImportError, 0),
+	}
+
+	now := time.Now()
+
+	for i, e := range st.Entries {
+		if e.FITID == "" || e.Posted.IsZero() || e.Amount == 0 {
+			res.Errors = append(res.Errors, &models.ImportError{Row: i + 1, Message: "entry needs a FITID, a posted date and an amount"})
+			continue
+		}
+
+		// Overlapping statements, and sometimes a single file, repeat entries with the same FITID.
+		if imported[e.FITID] {
+			res.Duplicates++
+			continue
+		}
+
+		imported[e.FITID] = true
+
+		res.Transactions = append(res.Transactions, toTransaction(e, userId, acc, now))
+	}
+
+	if st.LedgerBalance != nil {
+		res.Balance, err = u.compareBalance(ctx, userId, accountId, st, res.Transactions)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !commit || len(res.Transactions) == 0 {
+		return res, nil
+	}
+
+	err = u.trxRepo.StoreBatch(ctx, res.Transactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Imported = len(res.Transactions)
+	res.Committed = true
+
+	return res, nil
+}
+
+func (u *importUsecase) ImportQIF(c context.Context, userId int, accountId int, r io.Reader, dateOrder string, commit bool) (*models.ImportResult, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if dateOrder == "" {
+		dateOrder = "mdy"
+	}
+
+	if dateOrder != "mdy" && dateOrder != "dmy" {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	acc, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries, rowErrors := qif.Read(r, dateOrder)
+
+	categories, err := newCategoryResolver(ctx, u.categoryRepo, userId, commit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ImportResult{
+		Transactions: make([]*models.Transaction, 0, len(entries)),
+		Errors:       rowErrors,
+	}
+
+	now := time.Now()
+
+	for _, e := range entries {
+		trx, message, err := qifTransaction(ctx, e, categories, userId, acc, now)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if message != "" {
+			res.Errors = append(res.Errors, &models.ImportError{Row: e.Line, Message: message})
+			continue
+		}
+
+		res.Transactions = append(res.Transactions, trx)
+	}
+
+	if !commit || len(res.Transactions) == 0 {
+		return res, nil
+	}
+
+	err = u.trxRepo.StoreBatch(ctx, res.Transactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Imported = len(res.Transactions)
+	res.Committed = true
+
+	return res, nil
+}
+
+func (u *importUsecase) ExportQIF(c context.Context, userId int, accountId int, from time.Time, to time.Time, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if to.Before(from) {
+		return helpers.ErrBadParamInput
+	}
+
+	acc, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return err
+	}
+
+	filters := map[string]interface{}{
+		"accountId": accountId,
+		"from":      from,
+		"to":        to,
+	}
+
+	list, _, err := u.trxRepo.FetchAll(ctx, userId, filters, "", 0, 0)
+
+	if err != nil {
+		return err
+	}
+
+	categories, err := u.categoryRepo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return err
+	}
+
+	paths := category.Paths(categories, ":")
+
+	qw, err := qif.NewWriter(w, qifAccountType(acc))
+
+	if err != nil {
+		return err
+	}
+
+	for _, trx := range list {
+		if err = qw.Write(qifEntry(trx, paths)); err != nil {
+			return err
+		}
+	}
+
+	return qw.Flush()
+}
+
+func toTransaction(e *ofx.Entry, userId int, acc *models.Account, now time.Time) *models.Transaction {
+	trx := &models.Transaction{
+		UserID:    userId,
+		Account:   *acc,
+		Date:      e.Posted,
+		FitID:     e.FITID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	trx.Name = truncate(e.Name, 55)
+	trx.Description = e.Memo
+
+	if trx.Name == "" {
+		trx.Name = truncate(e.Memo, 55)
+	}
+
+	if trx.Name == "" {
+		trx.Name = e.Type
+	}
+
+	if trx.Description == "" {
+		trx.Description = e.Name
+	}
+
+	if e.Amount > 0 {
+		trx.Type = "in"
+		trx.AmountIn = e.Amount
+	} else {
+		trx.Type = "out"
+		trx.AmountOut = -e.Amount
+	}
+
+	return trx
+}
+
+// compareBalance computes the balance of the account on the statement's ledger date, counting the new
+// transactions as if they were already stored.
+func (u *importUsecase) compareBalance(ctx context.Context, userId int, accountId int, st *ofx.Statement, pending []*models.Transaction) (*models.ImportBalance, error) {
+	current, err := u.balanceRepo.FetchAsOf(ctx, userId, accountId, st.LedgerDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	computed := current.Balance
+
+	for _, trx := range pending {
+		if !trx.Date.After(st.LedgerDate) {
+			computed += trx.AmountIn - trx.AmountOut
+		}
+	}
+
+	return &models.ImportBalance{
+		AsOf:            st.LedgerDate,
+		LedgerBalance:   *st.LedgerBalance,
+		ComputedBalance: computed,
+		Difference:      *st.LedgerBalance - computed,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/arham09/fin-api/modules/importer/qif"
+)
+
+// categoryResolver finds the categories named by QIF "Parent:Child" paths. Paths are compared without regard
+// to case. When committing, missing categories are created with the type of the transaction using them;
+// otherwise they are returned by name only so the preview shows where each entry would be filed.
+type categoryResolver struct {
+	repo   category.Repository
+	userId int
+	commit bool
+	byPath map[string]*models.Category
+}
+
+func newCategoryResolver(ctx context.Context, repo category.Repository, userId int, commit bool) (*categoryResolver, error) {
+	list, err := repo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	paths := category.Paths(list, ":")
+	byPath := make(map[string]*models.Category, len(list))
+
+	for _, c := range list {
+		byPath[strings.ToLower(paths[c.ID])] = c
+	}
+
+	return &categoryResolver{repo, userId, commit, byPath}, nil
+}
+
+// resolve returns the category for path, or nil when path is empty, names a transfer account ("[Savings]")
+// or belongs to a category of the other type.
+func (r *categoryResolver) resolve(ctx context.Context, path string, kind string) (*models.Category, error) {
+	names := make([]string, 0)
+
+	for _, name := range strings.Split(path, ":") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, truncate(name, 55))
+		}
+	}
+
+	if len(names) == 0 || strings.HasPrefix(path, "[") {
+		return nil, nil
+	}
+
+	full := strings.Join(names, ":")
+
+	if c, ok := r.byPath[strings.ToLower(full)]; ok {
+		if c.Type != kind {
+			return nil, nil
+		}
+
+		return c, nil
+	}
+
+	if !r.commit {
+		c := &models.Category{Name: full, Type: kind}
+		r.byPath[strings.ToLower(full)] = c
+
+		return c, nil
+	}
+
+	var parent *models.Category
+
+	for i := range names {
+		key := strings.ToLower(strings.Join(names[:i+1], ":"))
+
+		if c, ok := r.byPath[key]; ok {
+			if c.Type != kind {
+				return nil, nil
+			}
+
+			parent = c
+			continue
+		}
+
+		now := time.Now()
+		c := &models.Category{UserID: r.userId, Name: names[i], Type: kind, CreatedAt: now, UpdatedAt: now}
+
+		if parent != nil {
+			c.ParentID = parent.ID
+		}
+
+		if err := r.repo.Store(ctx, c); err != nil {
+			return nil, err
+		}
+
+		r.byPath[key] = c
+		parent = c
+	}
+
+	return parent, nil
+}
+
+// qifTransaction maps a QIF entry to a transaction of acc. A non-empty message reports an entry that cannot be
+// imported.
+func qifTransaction(ctx context.Context, e *qif.Entry, categories *categoryResolver, userId int, acc *models.Account, now time.Time) (*models.Transaction, string, error) {
+	if e.Amount == 0 {
+		return nil, "record has no amount", nil
+	}
+
+	trx := &models.Transaction{
+		UserID:      userId,
+		Account:     *acc,
+		Date:        e.Date,
+		Name:        truncate(e.Payee, 55),
+		Description: e.Memo,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if trx.Name == "" {
+		trx.Name = truncate(e.Memo, 55)
+	}
+
+	if trx.Name == "" {
+		trx.Name = "Imported transaction"
+	}
+
+	if trx.Description == "" {
+		trx.Description = e.Payee
+	}
+
+	amount := e.Amount
+
+	if amount > 0 {
+		trx.Type = "in"
+		trx.AmountIn = amount
+	} else {
+		trx.Type = "out"
+		trx.AmountOut = -amount
+	}
+
+	var total models.Money
+
+	for _, s := range e.Splits {
+		if s.Amount == 0 {
+			continue
+		}
+
+		if (s.Amount > 0) != (amount > 0) {
+			return nil, "split amounts must have the sign of the transaction", nil
+		}
+
+		split := &models.TransactionSplit{Amount: s.Amount, Memo: truncate(s.Memo, 255)}
+
+		if split.Amount < 0 {
+			split.Amount = -split.Amount
+		}
+
+		total += split.Amount
+
+		cat, err := categories.resolve(ctx, s.Category, trx.Type)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		split.Category = cat
+		trx.Splits = append(trx.Splits, split)
+	}
+
+	if len(trx.Splits) > 0 && total != trx.AmountIn+trx.AmountOut {
+		return nil, "split amounts do not add up to the transaction amount", nil
+	}
+
+	if len(trx.Splits) == 0 {
+		cat, err := categories.resolve(ctx, e.Category, trx.Type)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		trx.Category = cat
+	}
+
+	return trx, "", nil
+}
+
+// qifAccountType returns the QIF section type for an account.
+func qifAccountType(acc *models.Account) string {
+	kind := strings.ToLower(acc.Type)
+
+	switch {
+	case strings.Contains(kind, "cash"):
+		return "Cash"
+	case strings.Contains(kind, "credit"):
+		return "CCard"
+	default:
+		return "Bank"
+	}
+}
+
+// qifEntry maps a transaction to a QIF entry, naming categories by their full path.
+func qifEntry(trx *models.Transaction, paths map[int]string) *qif.Entry {
+	e := &qif.Entry{
+		Date:   trx.Date,
+		Amount: trx.AmountIn - trx.AmountOut,
+		Payee:  trx.Name,
+		Memo:   trx.Description,
+	}
+
+	switch trx.Cleared {
+	case "cleared":
+		e.Cleared = "*"
+	case "reconciled":
+		e.Cleared = "X"
+	}
+
+	if e.Memo == e.Payee {
+		e.Memo = ""
+	}
+
+	if trx.Category != nil {
+		e.Category = paths[trx.Category.ID]
+	}
+
+	for _, s := range trx.Splits {
+		split := &qif.Split{Memo: s.Memo, Amount: s.Amount}
+
+		if trx.AmountOut > 0 {
+			split.Amount = -s.Amount
+		}
+
+		if s.Category != nil {
+			split.Category = paths[s.Category.ID]
+		}
+
+		e.Splits = append(e.Splits, split)
+	}
+
+	return e
+}
@@ -0,0 +1,291 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/investment"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type TradeRequest struct {
+	SecurityID int             `json:"securityId" validate:"required"`
+	Kind       string          `json:"kind" validate:"required,oneof=buy sell dividend"`
+	Quantity   models.Quantity `json:"quantity"`
+	Price      models.Money    `json:"price"`
+	Fee        models.Money    `json:"fee"`
+	Amount     models.Money    `json:"amount"`
+	CostBasis  string          `json:"costBasis" validate:"omitempty,oneof=fifo average"`
+	Date       string          `json:"date"`
+}
+
+type InvestmentHandler struct {
+	InvestmentUsecase investment.Usecase
+}
+
+func NewInvestmentHandler(e *echo.Echo, iu investment.Usecase, middleware *middleware.Middleware) {
+	handler := &InvestmentHandler{
+		InvestmentUsecase: iu,
+	}
+
+	e.GET("/v1/account/:id/trade", handler.FetchAll, middleware.Authorize)
+	e.POST("/v1/account/:id/trade", handler.Create, middleware.Authorize)
+	e.GET("/v1/account/:id/holdings", handler.FetchHoldings, middleware.Authorize)
+	e.GET("/v1/trade/:id", handler.FetchById, middleware.Authorize)
+	e.DELETE("/v1/trade/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowTrade godoc
+// @Summary Show List Trade
+// @Description get the trades of an investment account, oldest first
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Success 200 {array} models.Trade
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/trade [get]
+func (h *InvestmentHandler) FetchAll(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.InvestmentUsecase.FetchAll(ctx, c.Get("userId").(int), accountId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateTrade godoc
+// @Summary Create a Trade
+// @Description Buy, sell or collect a dividend on a security in an account of type investment, dated date (YYYY-MM-DD) or today. Buys and sells take a quantity, a price per unit and a fee; sells take their cost from the lots held with costBasis fifo (the default) or average. Dividends take the amount received. The cash moved is posted to the account as a transaction
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param trade body TradeRequest true "TradeRequest Body"
+// @Success 201 {object} models.Trade
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/trade [post]
+func (h *InvestmentHandler) Create(c echo.Context) error {
+	var req TradeRequest
+
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	t := &models.Trade{
+		UserID:    c.Get("userId").(int),
+		AccountID: accountId,
+		Security:  models.Security{ID: req.SecurityID},
+		Kind:      req.Kind,
+		Quantity:  req.Quantity,
+		Price:     req.Price,
+		Fee:       req.Fee,
+		Amount:    req.Amount,
+		CostBasis: req.CostBasis,
+	}
+
+	if req.Date != "" {
+		t.Date, err = time.Parse("2006-01-02", req.Date)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+	}
+
+	err = h.InvestmentUsecase.Create(ctx, t)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, t)
+}
+
+// ShowHoldings godoc
+// @Summary Show Holdings
+// @Description get the lots of an investment account held on date with their cost, market value and unrealized gain, along with the gains realized and dividends received up to date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param date query string false "value the holdings on this date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.Portfolio
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/holdings [get]
+func (h *InvestmentHandler) FetchHoldings(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	date := time.Now()
+
+	if param := c.QueryParam("date"); param != "" {
+		date, err = time.Parse("2006-01-02", param)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+	}
+
+	res, err := h.InvestmentUsecase.Holdings(ctx, c.Get("userId").(int), accountId, date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowTrade godoc
+// @Summary Show a Trade
+// @Description get trade by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Trade id"
+// @Success 200 {object} models.Trade
+// @Header 200 {string} Token "qwerty"
+// @Router /trade/{id} [get]
+func (h *InvestmentHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.InvestmentUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteTrade godoc
+// @Summary Delete Trade
+// @Description Delete trade by ID together with its transaction. A buy whose units were sold later cannot be deleted
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Trade id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /trade/{id} [delete]
+func (h *InvestmentHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.InvestmentUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func isRequestValid(m *TradeRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict, helpers.ErrLinkedTrade:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrPeriodClosed:
+		return http.StatusLocked
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,115 @@
+package investment
+
+import (
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+)
+
+// AccountType is the account type whose accounts hold securities.
+const AccountType = "investment"
+
+// Trade kinds and cost basis methods.
+const (
+	KindBuy      = "buy"
+	KindSell     = "sell"
+	KindDividend = "dividend"
+
+	CostBasisFIFO    = "fifo"
+	CostBasisAverage = "average"
+)
+
+// Replay builds the lots left by trades, all of one security and in date order, setting the cost and realized gain
+// of every sell on the way. A sell takes its units from the oldest lots first; under the average method its cost is
+// the average cost of every unit held, and the lots left share the remaining cost evenly. It fails with
+// ErrBadParamInput when a sell is larger than the units held at the time.
+func Replay(trades []*models.Trade) ([]*models.Lot, error) {
+	lots := make([]*models.Lot, 0)
+
+	for _, t := range trades {
+		switch t.Kind {
+		case KindBuy:
+			lots = append(lots, &models.Lot{
+				TradeID:   t.ID,
+				Date:      t.Date,
+				Quantity:  t.Quantity,
+				Remaining: t.Quantity,
+				Cost:      t.Amount,
+			})
+		case KindSell:
+			var err error
+
+			lots, err = sell(lots, t)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return lots, nil
+}
+
+// sell takes the units of t out of lots and returns the lots still open.
+func sell(lots []*models.Lot, t *models.Trade) ([]*models.Lot, error) {
+	var held models.Quantity
+	var heldCost models.Money
+
+	for _, l := range lots {
+		held += l.Remaining
+		heldCost += l.Cost
+	}
+
+	if t.Quantity > held {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	var cost models.Money
+	left := t.Quantity
+
+	for _, l := range lots {
+		if left == 0 {
+			break
+		}
+
+		take := l.Remaining
+
+		if take > left {
+			take = left
+		}
+
+		part := l.Cost.Prorate(take, l.Remaining)
+		cost += part
+		l.Cost -= part
+		l.Remaining -= take
+		left -= take
+	}
+
+	open := make([]*models.Lot, 0, len(lots))
+
+	for _, l := range lots {
+		if l.Remaining > 0 {
+			open = append(open, l)
+		}
+	}
+
+	if t.CostBasis == CostBasisAverage {
+		cost = heldCost.Prorate(t.Quantity, held)
+		remainingCost := heldCost - cost
+		remaining := held - t.Quantity
+
+		for i, l := range open {
+			if i == len(open)-1 {
+				l.Cost = remainingCost
+				break
+			}
+
+			l.Cost = (heldCost - cost).Prorate(l.Remaining, remaining)
+			remainingCost -= l.Cost
+		}
+	}
+
+	t.Cost = cost
+	t.RealizedGain = t.Amount - cost
+
+	return open, nil
+}
@@ -0,0 +1,143 @@
+package investment
+
+import (
+	"testing"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+)
+
+func qty(s string) models.Quantity {
+	q, err := models.ParseQuantity(s)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+func money(s string) models.Money {
+	m, err := models.ParseMoney(s)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func buy(id int, quantity string, amount string) *models.Trade {
+	return &models.Trade{ID: id, Kind: KindBuy, Quantity: qty(quantity), Amount: money(amount)}
+}
+
+func sellTrade(id int, basis string, quantity string, amount string) *models.Trade {
+	return &models.Trade{ID: id, Kind: KindSell, CostBasis: basis, Quantity: qty(quantity), Amount: money(amount)}
+}
+
+func TestReplay(t *testing.T) {
+	type lot struct {
+		tradeId   int
+		remaining string
+		cost      string
+	}
+
+	tests := []struct {
+		name   string
+		trades []*models.Trade
+		cost   string
+		gain   string
+		lots   []lot
+	}{
+		{
+			name:   "fifo sells the oldest lot first",
+			trades: []*models.Trade{buy(1, "10", "1000"), buy(2, "10", "1200"), sellTrade(3, CostBasisFIFO, "15", "2000")},
+			cost:   "1600",
+			gain:   "400",
+			lots:   []lot{{tradeId: 2, remaining: "5", cost: "600"}},
+		},
+		{
+			name:   "average prices every unit held the same",
+			trades: []*models.Trade{buy(1, "10", "1000"), buy(2, "10", "1200"), sellTrade(3, CostBasisAverage, "15", "2000")},
+			cost:   "1650",
+			gain:   "350",
+			lots:   []lot{{tradeId: 2, remaining: "5", cost: "550"}},
+		},
+		{
+			name:   "average spreads the remaining cost over open lots",
+			trades: []*models.Trade{buy(1, "10", "1000"), buy(2, "10", "1200"), sellTrade(3, CostBasisAverage, "5", "500")},
+			cost:   "550",
+			gain:   "-50",
+			lots:   []lot{{tradeId: 1, remaining: "5", cost: "550"}, {tradeId: 2, remaining: "10", cost: "1100"}},
+		},
+		{
+			name:   "fifo rounds a fractional share of cost",
+			trades: []*models.Trade{buy(1, "3", "100"), sellTrade(2, CostBasisFIFO, "1", "40")},
+			cost:   "33.3333",
+			gain:   "6.6667",
+			lots:   []lot{{tradeId: 1, remaining: "2", cost: "66.6667"}},
+		},
+		{
+			name:   "selling everything closes every lot",
+			trades: []*models.Trade{buy(1, "0.5", "50"), buy(2, "1.25", "130"), sellTrade(3, CostBasisFIFO, "1.75", "200")},
+			cost:   "180",
+			gain:   "20",
+			lots:   []lot{},
+		},
+		{
+			name:   "dividends leave lots alone",
+			trades: []*models.Trade{buy(1, "2", "100"), {ID: 2, Kind: KindDividend, Amount: money("5")}, sellTrade(3, CostBasisFIFO, "1", "60")},
+			cost:   "50",
+			gain:   "10",
+			lots:   []lot{{tradeId: 1, remaining: "1", cost: "50"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lots, err := Replay(tt.trades)
+
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+
+			last := tt.trades[len(tt.trades)-1]
+
+			if last.Cost != money(tt.cost) || last.RealizedGain != money(tt.gain) {
+				t.Errorf("sell cost %s gain %s, want %s and %s", last.Cost, last.RealizedGain, tt.cost, tt.gain)
+			}
+
+			if len(lots) != len(tt.lots) {
+				t.Fatalf("Replay() left %d lots, want %d", len(lots), len(tt.lots))
+			}
+
+			for i, w := range tt.lots {
+				l := lots[i]
+
+				if l.TradeID != w.tradeId || l.Remaining != qty(w.remaining) || l.Cost != money(w.cost) {
+					t.Errorf("lot %d = trade %d, %s left costing %s, want trade %d, %s left costing %s", i, l.TradeID, l.Remaining, l.Cost, w.tradeId, w.remaining, w.cost)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayOversell(t *testing.T) {
+	tests := []struct {
+		name   string
+		trades []*models.Trade
+	}{
+		{name: "fifo", trades: []*models.Trade{buy(1, "10", "1000"), sellTrade(2, CostBasisFIFO, "10.00000001", "1000")}},
+		{name: "average", trades: []*models.Trade{buy(1, "10", "1000"), sellTrade(2, CostBasisAverage, "11", "1100")}},
+		{name: "nothing held", trades: []*models.Trade{sellTrade(1, CostBasisFIFO, "1", "100")}},
+		{name: "after an earlier sell", trades: []*models.Trade{buy(1, "10", "1000"), sellTrade(2, CostBasisFIFO, "6", "600"), sellTrade(3, CostBasisFIFO, "5", "500")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Replay(tt.trades); err != helpers.ErrBadParamInput {
+				t.Errorf("Replay() error = %v, want %v", err, helpers.ErrBadParamInput)
+			}
+		})
+	}
+}
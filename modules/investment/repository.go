@@ -0,0 +1,20 @@
+package investment
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	// FetchAll lists the trades of an account in the order they are replayed: by date, then by id. A non-zero
+	// securityId limits them to one security.
+	FetchAll(ctx context.Context, userId int, accountId int, securityId int) ([]*models.Trade, error)
+	FetchById(ctx context.Context, userId int, id int) (*models.Trade, error)
+	// Store saves the trade and posts its amount to the account as a linked transaction.
+	Store(ctx context.Context, t *models.Trade) error
+	// Delete removes the trade and moves its transaction to the trash, out of the account balance.
+	Delete(ctx context.Context, userId int, id int) error
+	// UpdateGains saves the cost and realized gain of sells after a replay changed them.
+	UpdateGains(ctx context.Context, trades []*models.Trade) error
+}
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	"github.com/arham09/fin-api/modules/investment"
+	pdr "github.com/arham09/fin-api/modules/period/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const selectTrade = `SELECT tr.id, tr.user_id, tr.account_id, tr.security_id, s.user_id, s.symbol, s.name, s.currency, tr.kind,
+	tr.quantity, tr.price, tr.fee, tr.amount, tr.cost_basis, tr.cost, tr.realized_gain, tr.transaction_id, tr.trade_date,
+	tr.status, tr.created_at, tr.updated_at
+	FROM trades tr JOIN securities s ON tr.security_id=s.id`
+
+// trxNames names the transaction posted for each kind of trade.
+var trxNames = map[string]string{
+	investment.KindBuy:      "Buy",
+	investment.KindSell:     "Sell",
+	investment.KindDividend: "Dividend",
+}
+
+// queryer is the connection, or a database transaction when a read must see the writes made in it.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type mySqlInvestmentRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlInvestmentRepository(Conn *sql.DB) investment.Repository {
+	return &mySqlInvestmentRepository{Conn}
+}
+
+func (m *mySqlInvestmentRepository) fetch(ctx context.Context, q queryer, query string, args ...interface{}) ([]*models.Trade, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Trade, 0)
+
+	for rows.Next() {
+		t := new(models.Trade)
+		costBasis := sql.NullString{}
+		transactionId := sql.NullInt64{}
+		status := int(0)
+
+		err = rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.AccountID,
+			&t.Security.ID,
+			&t.Security.UserID,
+			&t.Security.Symbol,
+			&t.Security.Name,
+			&t.Security.Currency,
+			&t.Kind,
+			&t.Quantity,
+			&t.Price,
+			&t.Fee,
+			&t.Amount,
+			&costBasis,
+			&t.Cost,
+			&t.RealizedGain,
+			&transactionId,
+			&t.Date,
+			&status,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		t.CostBasis = costBasis.String
+		t.TransactionID = int(transactionId.Int64)
+
+		if status == 1 {
+			t.Status = "active"
+		} else {
+			t.Status = "inactive"
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlInvestmentRepository) FetchAll(ctx context.Context, userId int, accountId int, securityId int) ([]*models.Trade, error) {
+	query := selectTrade + ` WHERE tr.status=1 AND tr.user_id = ? AND tr.account_id = ?`
+	args := []interface{}{userId, accountId}
+
+	if securityId != 0 {
+		query = query + ` AND tr.security_id = ?`
+		args = append(args, securityId)
+	}
+
+	return m.fetch(ctx, m.Conn, query+` ORDER BY tr.trade_date, tr.id`, args...)
+}
+
+func (m *mySqlInvestmentRepository) FetchById(ctx context.Context, userId int, id int) (*models.Trade, error) {
+	list, err := m.fetch(ctx, m.Conn, selectTrade+` WHERE tr.status=1 AND tr.user_id = ? AND tr.id = ?`, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlInvestmentRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlInvestmentRepository) adjustBalance(ctx context.Context, tx *sql.Tx, userId int, accountId int, amount models.Money) error {
+	query := `UPDATE accounts SET balance = balance + CAST(? AS DECIMAL(19,4)) WHERE user_id = ? AND id = ?`
+
+	_, err := tx.ExecContext(ctx, query, amount, userId, accountId)
+
+	return err
+}
+
+// cash returns the amount t adds to the balance of its account: buys take cash out, sells and dividends bring it in.
+func cash(t *models.Trade) models.Money {
+	if t.Kind == investment.KindBuy {
+		return -t.Amount
+	}
+
+	return t.Amount
+}
+
+// storeTrx posts the cash of t as a transaction of its account.
+func (m *mySqlInvestmentRepository) storeTrx(ctx context.Context, tx *sql.Tx, t *models.Trade) (int, error) {
+	query := `INSERT transactions SET user_id=?, trade_id=?, name=?, account_id=?, type=?, description=?, amount_in=?, amount_out=?, trx_date=?, created_at=?, updated_at=?`
+
+	name := fmt.Sprintf("%s %s", trxNames[t.Kind], t.Security.Symbol)
+	description := name
+	trxType, amountIn, amountOut := "in", t.Amount, models.Money(0)
+
+	if t.Kind != investment.KindDividend {
+		description = fmt.Sprintf("%s %s %s at %s", trxNames[t.Kind], t.Quantity, t.Security.Symbol, t.Price)
+	}
+
+	if t.Kind == investment.KindBuy {
+		trxType, amountIn, amountOut = "out", 0, t.Amount
+	}
+
+	res, err := tx.ExecContext(ctx, query, t.UserID, t.ID, name, t.AccountID, trxType, description, amountIn, amountOut, t.Date.Format("2006-01-02"), t.CreatedAt, t.UpdatedAt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(lastID), nil
+}
+
+func (m *mySqlInvestmentRepository) Store(ctx context.Context, t *models.Trade) error {
+	query := `INSERT trades SET user_id=?, account_id=?, security_id=?, kind=?, quantity=?, price=?, fee=?, amount=?, cost_basis=?, cost=?, realized_gain=?, trade_date=?, created_at=?, updated_at=?`
+
+	costBasis := sql.NullString{String: t.CostBasis, Valid: t.CostBasis != ""}
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := pdr.CheckOpen(ctx, tx, t.UserID, t.Date); err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, t.UserID, t.AccountID, t.Security.ID, t.Kind, t.Quantity, t.Price, t.Fee, t.Amount, costBasis, t.Cost, t.RealizedGain, t.Date.Format("2006-01-02"), t.CreatedAt, t.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		t.ID = int(lastID)
+
+		t.TransactionID, err = m.storeTrx(ctx, tx, t)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE trades SET transaction_id=? WHERE id = ?`, t.TransactionID, t.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.adjustBalance(ctx, tx, t.UserID, t.AccountID, cash(t)); err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, t.UserID, t.UserID, audit.EntityTransaction, t.TransactionID, "create", nil, t)
+	})
+}
+
+func (m *mySqlInvestmentRepository) Delete(ctx context.Context, userId int, id int) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		list, err := m.fetch(ctx, tx, selectTrade+` WHERE tr.status=1 AND tr.user_id = ? AND tr.id = ? FOR UPDATE`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			return helpers.ErrNotFound
+		}
+
+		t := list[0]
+
+		if err = pdr.CheckOpen(ctx, tx, userId, t.Date); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE trades SET status=0, updated_at=? WHERE user_id = ? AND id = ?`, time.Now(), userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `UPDATE transactions SET status=0, deleted_at=? WHERE status=1 AND user_id = ? AND trade_id = ?`, time.Now(), userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		// The transaction is already out of the balance when it went to the trash with its account.
+		if affect > 0 {
+			if err = m.adjustBalance(ctx, tx, userId, t.AccountID, -cash(t)); err != nil {
+				return err
+			}
+		}
+
+		return adr.Write(ctx, tx, userId, userId, audit.EntityTransaction, t.TransactionID, "delete", t, nil)
+	})
+}
+
+func (m *mySqlInvestmentRepository) UpdateGains(ctx context.Context, trades []*models.Trade) error {
+	query := `UPDATE trades SET cost=?, realized_gain=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		for _, t := range trades {
+			_, err := tx.ExecContext(ctx, query, t.Cost, t.RealizedGain, t.UpdatedAt, t.UserID, t.ID)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
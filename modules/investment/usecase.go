@@ -0,0 +1,19 @@
+package investment
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int, accountId int) ([]*models.Trade, error)
+	FetchById(c context.Context, userId int, id int) (*models.Trade, error)
+	// Create and Delete replay the trades of the security, rejecting a trade that would sell more units than held
+	// at the time, and update the realized gain of the sells that follow.
+	Create(c context.Context, t *models.Trade) error
+	Delete(c context.Context, userId int, id int) error
+	// Holdings values the lots of an account held on date.
+	Holdings(c context.Context, userId int, accountId int, date time.Time) (*models.Portfolio, error)
+}
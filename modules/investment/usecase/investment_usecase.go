@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/investment"
+	"github.com/arham09/fin-api/modules/security"
+)
+
+type investmentUsecase struct {
+	investmentRepo investment.Repository
+	accountRepo    account.Repository
+	securityRepo   security.Repository
+	contextTimeout time.Duration
+}
+
+func NewInvestmentUsecase(i investment.Repository, a account.Repository, s security.Repository, timeout time.Duration) investment.Usecase {
+	return &investmentUsecase{
+		investmentRepo: i,
+		accountRepo:    a,
+		securityRepo:   s,
+		contextTimeout: timeout,
+	}
+}
+
+// fetchAccount returns the account of userId with the given id, which must be an investment account.
+func (u *investmentUsecase) fetchAccount(ctx context.Context, userId int, accountId int) (*models.Account, error) {
+	acc, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Type != investment.AccountType {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	return acc, nil
+}
+
+func (u *investmentUsecase) FetchAll(c context.Context, userId int, accountId int) ([]*models.Trade, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if _, err := u.fetchAccount(ctx, userId, accountId); err != nil {
+		return nil, err
+	}
+
+	return u.investmentRepo.FetchAll(ctx, userId, accountId, 0)
+}
+
+func (u *investmentUsecase) FetchById(c context.Context, userId int, id int) (*models.Trade, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.investmentRepo.FetchById(ctx, userId, id)
+}
+
+// normalize checks the figures of t for its kind and works out the cash it moves.
+func normalize(t *models.Trade) error {
+	switch t.Kind {
+	case investment.KindBuy, investment.KindSell:
+		if t.Quantity <= 0 || t.Price <= 0 || t.Fee < 0 {
+			return helpers.ErrBadParamInput
+		}
+
+		gross := t.Price.MulQuantity(t.Quantity)
+
+		if t.Kind == investment.KindBuy {
+			t.Amount = gross + t.Fee
+			t.CostBasis = ""
+
+			return nil
+		}
+
+		t.Amount = gross - t.Fee
+
+		if t.CostBasis == "" {
+			t.CostBasis = investment.CostBasisFIFO
+		}
+
+		if t.Amount < 0 || (t.CostBasis != investment.CostBasisFIFO && t.CostBasis != investment.CostBasisAverage) {
+			return helpers.ErrBadParamInput
+		}
+	case investment.KindDividend:
+		if t.Amount <= 0 {
+			return helpers.ErrBadParamInput
+		}
+
+		t.Quantity, t.Price, t.Fee, t.CostBasis = 0, 0, 0, ""
+	default:
+		return helpers.ErrBadParamInput
+	}
+
+	return nil
+}
+
+// gains keeps the cost and realized gain of each sell in trades, to tell which ones a replay changed.
+func gains(trades []*models.Trade) map[int][2]models.Money {
+	res := make(map[int][2]models.Money, len(trades))
+
+	for _, t := range trades {
+		if t.Kind == investment.KindSell {
+			res[t.ID] = [2]models.Money{t.Cost, t.RealizedGain}
+		}
+	}
+
+	return res
+}
+
+// updateGains saves the sells of trades whose cost or realized gain differs from before.
+func (u *investmentUsecase) updateGains(ctx context.Context, trades []*models.Trade, before map[int][2]models.Money) error {
+	changed := make([]*models.Trade, 0)
+
+	for _, t := range trades {
+		old, ok := before[t.ID]
+
+		if !ok || (old[0] == t.Cost && old[1] == t.RealizedGain) {
+			continue
+		}
+
+		t.UpdatedAt = time.Now()
+		changed = append(changed, t)
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return u.investmentRepo.UpdateGains(ctx, changed)
+}
+
+func (u *investmentUsecase) Create(c context.Context, t *models.Trade) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	acc, err := u.fetchAccount(ctx, t.UserID, t.AccountID)
+
+	if err != nil {
+		return err
+	}
+
+	sec, err := u.securityRepo.FetchById(ctx, t.UserID, t.Security.ID)
+
+	if err != nil {
+		return err
+	}
+
+	// Trades are settled in the account currency, so the security must be priced in it too.
+	if sec.Currency != acc.Currency {
+		return helpers.ErrBadParamInput
+	}
+
+	t.Security = *sec
+
+	if err = normalize(t); err != nil {
+		return err
+	}
+
+	if t.Date.IsZero() {
+		t.Date = time.Now()
+	}
+
+	t.Date = time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	trades, err := u.investmentRepo.FetchAll(ctx, t.UserID, t.AccountID, sec.ID)
+
+	if err != nil {
+		return err
+	}
+
+	before := gains(trades)
+
+	// A trade dated like earlier ones is replayed after them.
+	trades = append(trades, t)
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Date.Before(trades[j].Date)
+	})
+
+	if _, err = investment.Replay(trades); err != nil {
+		return err
+	}
+
+	t.Status = "active"
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+
+	err = u.investmentRepo.Store(ctx, t)
+
+	if err != nil {
+		return err
+	}
+
+	return u.updateGains(ctx, trades, before)
+}
+
+func (u *investmentUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	t, err := u.investmentRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = u.accountRepo.FetchById(ctx, userId, t.AccountID); err != nil {
+		return err
+	}
+
+	trades, err := u.investmentRepo.FetchAll(ctx, userId, t.AccountID, t.Security.ID)
+
+	if err != nil {
+		return err
+	}
+
+	before := gains(trades)
+	rest := make([]*models.Trade, 0, len(trades))
+
+	for _, other := range trades {
+		if other.ID != id {
+			rest = append(rest, other)
+		}
+	}
+
+	// A buy whose units were sold later cannot go away on its own.
+	if _, err = investment.Replay(rest); err == helpers.ErrBadParamInput {
+		return helpers.ErrConflict
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = u.investmentRepo.Delete(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.updateGains(ctx, rest, before)
+}
+
+func (u *investmentUsecase) Holdings(c context.Context, userId int, accountId int, date time.Time) (*models.Portfolio, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	acc, err := u.fetchAccount(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := u.investmentRepo.FetchAll(ctx, userId, accountId, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	bySecurity := investment.GroupBySecurity(trades, day)
+
+	res := &models.Portfolio{
+		AccountID: accountId,
+		Currency:  acc.Currency,
+		Date:      day,
+		Holdings:  make([]*models.Holding, 0, len(bySecurity)),
+	}
+
+	for id, list := range bySecurity {
+		price, err := u.securityRepo.FetchPrice(ctx, id, day)
+
+		if err != nil && err != helpers.ErrNotFound {
+			return nil, err
+		}
+
+		h, err := investment.Value(list, price)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.Cost += h.Cost
+		res.MarketValue += h.MarketValue
+		res.UnrealizedGain += h.UnrealizedGain
+		res.RealizedGain += h.RealizedGain
+		res.Dividends += h.Dividends
+		res.Holdings = append(res.Holdings, h)
+	}
+
+	sort.Slice(res.Holdings, func(i, j int) bool {
+		return res.Holdings[i].Security.Symbol < res.Holdings[j].Security.Symbol
+	})
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package investment
+
+import (
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Value replays trades, all of one security and dated on or before the day valued, into the position they leave,
+// priced with the latest price known then: price, when not nil, or the price paid in the last trade, whichever is
+// newer.
+func Value(trades []*models.Trade, price *models.SecurityPrice) (*models.Holding, error) {
+	lots, err := Replay(trades)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h := &models.Holding{
+		Security: trades[0].Security,
+		Lots:     lots,
+	}
+
+	for _, l := range lots {
+		h.Quantity += l.Remaining
+		h.Cost += l.Cost
+	}
+
+	for _, t := range trades {
+		switch t.Kind {
+		case KindSell:
+			h.RealizedGain += t.RealizedGain
+		case KindDividend:
+			h.Dividends += t.Amount
+		}
+
+		if t.Kind != KindDividend {
+			priceDate := t.Date
+			h.Price, h.PriceDate = t.Price, &priceDate
+		}
+	}
+
+	if price != nil && (h.PriceDate == nil || !price.Date.Before(*h.PriceDate)) {
+		h.Price, h.PriceDate = price.Price, &price.Date
+	}
+
+	h.MarketValue = h.Price.MulQuantity(h.Quantity)
+	h.UnrealizedGain = h.MarketValue - h.Cost
+
+	return h, nil
+}
+
+// PriceOn returns the latest of prices, sorted newest first, dated on or before day, or nil when there is none.
+func PriceOn(prices []*models.SecurityPrice, day time.Time) *models.SecurityPrice {
+	for _, p := range prices {
+		if !p.Date.After(day) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// GroupBySecurity splits trades by security, keeping their order, and drops those dated after day.
+func GroupBySecurity(trades []*models.Trade, day time.Time) map[int][]*models.Trade {
+	res := make(map[int][]*models.Trade)
+
+	for _, t := range trades {
+		if !t.Date.After(day) {
+			res[t.Security.ID] = append(res[t.Security.ID], t)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,76 @@
+package investment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+func date(d int) time.Time {
+	return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPriceOn(t *testing.T) {
+	prices := []*models.SecurityPrice{
+		{ID: 3, Date: date(20)},
+		{ID: 2, Date: date(10)},
+		{ID: 1, Date: date(1)},
+	}
+
+	tests := []struct {
+		day  time.Time
+		want int
+	}{
+		{day: date(25), want: 3},
+		{day: date(20), want: 3},
+		{day: date(19), want: 2},
+		{day: date(10), want: 2},
+		{day: date(5), want: 1},
+		{day: date(1), want: 1},
+		{day: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got := PriceOn(prices, tt.day)
+
+		if (got == nil && tt.want != 0) || (got != nil && got.ID != tt.want) {
+			t.Errorf("PriceOn(%s) = %+v, want price %d", tt.day.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestValuePrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		price *models.SecurityPrice
+		want  string
+		date  time.Time
+	}{
+		{name: "no posted price", want: "100", date: date(10)},
+		{name: "posted after the trade", price: &models.SecurityPrice{Price: money("120"), Date: date(15)}, want: "120", date: date(15)},
+		{name: "posted on the trade date", price: &models.SecurityPrice{Price: money("110"), Date: date(10)}, want: "110", date: date(10)},
+		{name: "posted before the trade", price: &models.SecurityPrice{Price: money("90"), Date: date(5)}, want: "100", date: date(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trade := buy(1, "2", "200")
+			trade.Price, trade.Date = money("100"), date(10)
+
+			h, err := Value([]*models.Trade{trade}, tt.price)
+
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+
+			if h.Price != money(tt.want) || !h.PriceDate.Equal(tt.date) {
+				t.Errorf("Value() priced at %s on %s, want %s on %s", h.Price, h.PriceDate.Format("2006-01-02"), tt.want, tt.date.Format("2006-01-02"))
+			}
+
+			if h.MarketValue != h.Price.MulQuantity(qty("2")) {
+				t.Errorf("Value() market value = %s", h.MarketValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package loan
+
+import (
+	"math"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+)
+
+// AccountType is the account type whose accounts can carry loan terms.
+const AccountType = "loan"
+
+// Installment returns the fixed monthly payment repaying the principal of l with interest over its term.
+func Installment(l *models.Loan) models.Money {
+	n := float64(l.TermMonths)
+	r := float64(l.InterestRate) / math.Pow10(models.RateScale) / 1200
+
+	if r == 0 {
+		return l.Principal.DivRound(int64(l.TermMonths))
+	}
+
+	return models.Money(math.Round(float64(l.Principal) * r / (1 - math.Pow(1+r, -n))))
+}
+
+// DueDate returns the date installment number n of l falls due, moved to the last day of the month when the month
+// is shorter than the payment day.
+func DueDate(l *models.Loan, n int) time.Time {
+	month := time.Date(l.StartDate.Year(), l.StartDate.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	day := l.PaymentDay
+
+	if last := month.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return month.AddDate(0, 0, day-1)
+}
+
+// Schedule builds the amortization schedule of l. The last installment repays whatever principal the rounding of
+// the previous ones left.
+func Schedule(l *models.Loan) []*models.LoanInstallment {
+	payment := Installment(l)
+	balance := l.Principal
+	res := make([]*models.LoanInstallment, 0, l.TermMonths)
+
+	for n := 1; n <= l.TermMonths; n++ {
+		interest := l.MonthlyInterest(balance)
+		principal := payment - interest
+
+		if n == l.TermMonths || principal > balance {
+			principal = balance
+		}
+
+		balance -= principal
+
+		res = append(res, &models.LoanInstallment{
+			Number:    n,
+			Date:      DueDate(l, n),
+			Payment:   principal + interest,
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+
+		if balance == 0 {
+			break
+		}
+	}
+
+	return res
+}
+
+// SplitPayment splits amount paid to l into a month of interest on the outstanding principal and the principal
+// repaid. A payment smaller than the interest only pays interest; one repaying more than the outstanding principal
+// is rejected.
+func SplitPayment(l *models.Loan, amount models.Money) (principal models.Money, interest models.Money, err error) {
+	interest = l.MonthlyInterest(l.OutstandingPrincipal)
+
+	if amount <= interest {
+		return 0, amount, nil
+	}
+
+	principal = amount - interest
+
+	if principal > l.OutstandingPrincipal {
+		return 0, 0, helpers.ErrBadParamInput
+	}
+
+	return principal, interest, nil
+}
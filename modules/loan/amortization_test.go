@@ -0,0 +1,177 @@
+package loan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+)
+
+func newLoan(principal string, rate string, months int) *models.Loan {
+	p, err := models.ParseMoney(principal)
+
+	if err != nil {
+		panic(err)
+	}
+
+	r, err := models.ParseRate(rate)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &models.Loan{
+		Principal:            p,
+		InterestRate:         r,
+		TermMonths:           months,
+		PaymentDay:           31,
+		StartDate:            time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		OutstandingPrincipal: p,
+	}
+}
+
+func TestInstallment(t *testing.T) {
+	tests := []struct {
+		name string
+		loan *models.Loan
+		want models.Money
+	}{
+		{name: "zero rate", loan: newLoan("1200", "0", 12), want: 1000000},
+		{name: "zero rate rounds", loan: newLoan("1000", "0", 3), want: 3333333},
+		{name: "annuity", loan: newLoan("10000000", "12", 12), want: 8884878868},
+		{name: "short annuity", loan: newLoan("3000", "12", 3), want: 10200663},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Installment(tt.loan); got != tt.want {
+				t.Errorf("Installment() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	type row struct {
+		payment, principal, interest, balance models.Money
+	}
+
+	tests := []struct {
+		name string
+		loan *models.Loan
+		want []row
+	}{
+		{
+			name: "zero rate pays the remainder last",
+			loan: newLoan("1000", "0", 3),
+			want: []row{
+				{payment: 3333333, principal: 3333333, balance: 6666667},
+				{payment: 3333333, principal: 3333333, balance: 3333334},
+				{payment: 3333334, principal: 3333334, balance: 0},
+			},
+		},
+		{
+			name: "last installment absorbs rounding",
+			loan: newLoan("3000", "12", 3),
+			want: []row{
+				{payment: 10200663, principal: 9900663, interest: 300000, balance: 20099337},
+				{payment: 10200663, principal: 9999670, interest: 200993, balance: 10099667},
+				{payment: 10200664, principal: 10099667, interest: 100997, balance: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Schedule(tt.loan)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Schedule() has %d installments, want %d", len(got), len(tt.want))
+			}
+
+			for i, w := range tt.want {
+				g := got[i]
+
+				if g.Number != i+1 || g.Payment != w.payment || g.Principal != w.principal || g.Interest != w.interest || g.Balance != w.balance {
+					t.Errorf("installment %d = %+v, want %+v", i+1, g, w)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleRepaysPrincipal(t *testing.T) {
+	l := newLoan("10000000", "12", 12)
+	payment := Installment(l)
+	var repaid models.Money
+
+	list := Schedule(l)
+
+	for i, in := range list {
+		repaid += in.Principal
+
+		if in.Payment != in.Principal+in.Interest {
+			t.Errorf("installment %d pays %s, not principal plus interest", in.Number, in.Payment)
+		}
+
+		if i < len(list)-1 && in.Payment != payment {
+			t.Errorf("installment %d pays %s, want %s", in.Number, in.Payment, payment)
+		}
+	}
+
+	if repaid != l.Principal || list[len(list)-1].Balance != 0 {
+		t.Errorf("Schedule() repays %s of %s", repaid, l.Principal)
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	tests := []struct {
+		start time.Time
+		day   int
+		n     int
+		want  time.Time
+	}{
+		{start: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), day: 31, n: 1, want: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{start: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), day: 31, n: 2, want: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{start: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), day: 30, n: 3, want: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), day: 30, n: 1, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{start: time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC), day: 5, n: 2, want: time.Date(2027, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		l := &models.Loan{StartDate: tt.start, PaymentDay: tt.day}
+
+		if got := DueDate(l, tt.n); !got.Equal(tt.want) {
+			t.Errorf("DueDate(%s, day %d, %d) = %s, want %s", tt.start.Format("2006-01-02"), tt.day, tt.n, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestSplitPayment(t *testing.T) {
+	l := newLoan("3000", "12", 3)
+
+	tests := []struct {
+		name      string
+		amount    models.Money
+		principal models.Money
+		interest  models.Money
+		err       error
+	}{
+		{name: "installment", amount: 10200663, principal: 9900663, interest: 300000},
+		{name: "interest only", amount: 300000, interest: 300000},
+		{name: "less than interest", amount: 100000, interest: 100000},
+		{name: "pays off", amount: 30300000, principal: 30000000, interest: 300000},
+		{name: "overpays", amount: 30300001, err: helpers.ErrBadParamInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, interest, err := SplitPayment(l, tt.amount)
+
+			if err != tt.err || principal != tt.principal || interest != tt.interest {
+				t.Errorf("SplitPayment(%s) = %s, %s, %v, want %s, %s, %v", tt.amount, principal, interest, err, tt.principal, tt.interest, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/loan"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type LoanRequest struct {
+	Principal    models.Money `json:"principal" validate:"required"`
+	InterestRate models.Rate  `json:"interestRate"`
+	TermMonths   int          `json:"termMonths" validate:"required,min=1"`
+	PaymentDay   int          `json:"paymentDay" validate:"required,min=1,max=31"`
+	StartDate    string       `json:"startDate" validate:"required"`
+}
+
+type LoanHandler struct {
+	LoanUsecase loan.Usecase
+}
+
+func NewLoanHandler(e *echo.Echo, lu loan.Usecase, middleware *middleware.Middleware) {
+	handler := &LoanHandler{
+		LoanUsecase: lu,
+	}
+
+	e.GET("/v1/account/:id/loan", handler.FetchByAccount, middleware.Authorize)
+	e.POST("/v1/account/:id/loan", handler.Create, middleware.Authorize)
+	e.GET("/v1/account/:id/loan/schedule", handler.Schedule, middleware.Authorize)
+}
+
+// ShowLoan godoc
+// @Summary Show a Loan
+// @Description get the terms of the loan held by an account with its outstanding principal, monthly installment and the principal and interest split of every payment posted so far
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Success 200 {object} models.Loan
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/loan [get]
+func (h *LoanHandler) FetchByAccount(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.LoanUsecase.FetchByAccount(ctx, c.Get("userId").(int), accountId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateLoan godoc
+// @Summary Create a Loan
+// @Description Set the terms of the loan held by an account of type loan: the principal borrowed on startDate (YYYY-MM-DD), the annual interestRate in percent, the term in months and the paymentDay of the month installments are due. Every "in" transaction posted to the account afterwards is a payment, split automatically into interest on the outstanding principal and principal repaid
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param loan body LoanRequest true "LoanRequest Body"
+// @Success 201 {object} models.Loan
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/loan [post]
+func (h *LoanHandler) Create(c echo.Context) error {
+	var req LoanRequest
+
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "startDate should be formatted as YYYY-MM-DD",
+		})
+	}
+
+	l := &models.Loan{
+		UserID:       c.Get("userId").(int),
+		AccountID:    accountId,
+		Principal:    req.Principal,
+		InterestRate: req.InterestRate,
+		TermMonths:   req.TermMonths,
+		PaymentDay:   req.PaymentDay,
+		StartDate:    startDate,
+	}
+
+	err = h.LoanUsecase.Create(ctx, l)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, l)
+}
+
+// ShowLoanSchedule godoc
+// @Summary Show a Loan amortization schedule
+// @Description get the full amortization schedule of the loan held by an account on its original terms: the due date of every installment with its interest, principal and the balance left afterwards
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Success 200 {array} models.LoanInstallment
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/loan/schedule [get]
+func (h *LoanHandler) Schedule(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.LoanUsecase.Schedule(ctx, c.Get("userId").(int), accountId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func isRequestValid(m *LoanRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,13 @@
+package loan
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchByAccount(ctx context.Context, userId int, accountId int) (*models.Loan, error)
+	Store(ctx context.Context, l *models.Loan) error
+	FetchPayments(ctx context.Context, loanId int) ([]*models.LoanPayment, error)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/loan"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlLoanRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlLoanRepository(Conn *sql.DB) loan.Repository {
+	return &mySqlLoanRepository{Conn}
+}
+
+func (m *mySqlLoanRepository) FetchByAccount(ctx context.Context, userId int, accountId int) (*models.Loan, error) {
+	query := `SELECT id, user_id, account_id, principal, interest_rate, term_months, payment_day, start_date,
+		outstanding_principal, status, created_at, updated_at
+		FROM loans WHERE status=1 AND user_id = ? AND account_id = ?`
+
+	l := new(models.Loan)
+	status := int(0)
+
+	err := m.Conn.QueryRowContext(ctx, query, userId, accountId).Scan(
+		&l.ID,
+		&l.UserID,
+		&l.AccountID,
+		&l.Principal,
+		&l.InterestRate,
+		&l.TermMonths,
+		&l.PaymentDay,
+		&l.StartDate,
+		&l.OutstandingPrincipal,
+		&status,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	if status == 1 {
+		l.Status = "active"
+	} else {
+		l.Status = "inactive"
+	}
+
+	return l, nil
+}
+
+func (m *mySqlLoanRepository) Store(ctx context.Context, l *models.Loan) error {
+	query := `INSERT loans SET user_id=?, account_id=?, principal=?, interest_rate=?, term_months=?, payment_day=?,
+		start_date=?, outstanding_principal=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, l.UserID, l.AccountID, l.Principal, l.InterestRate, l.TermMonths, l.PaymentDay,
+		l.StartDate.Format("2006-01-02"), l.OutstandingPrincipal, l.CreatedAt, l.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	l.ID = int(lastID)
+
+	return nil
+}
+
+const selectPayment = `SELECT p.id, p.loan_id, p.transaction_id, p.payment_date, p.principal, p.interest,
+	p.outstanding_principal, p.created_at FROM loan_payments p`
+
+func (m *mySqlLoanRepository) fetchPayments(ctx context.Context, query string, args ...interface{}) ([]*models.LoanPayment, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.LoanPayment, 0)
+
+	for rows.Next() {
+		p := new(models.LoanPayment)
+
+		err = rows.Scan(
+			&p.ID,
+			&p.LoanID,
+			&p.TransactionID,
+			&p.Date,
+			&p.Principal,
+			&p.Interest,
+			&p.OutstandingPrincipal,
+			&p.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlLoanRepository) FetchPayments(ctx context.Context, loanId int) ([]*models.LoanPayment, error) {
+	query := selectPayment + ` WHERE p.status=1 AND p.loan_id = ? ORDER BY p.payment_date, p.id`
+
+	return m.fetchPayments(ctx, query, loanId)
+}
@@ -0,0 +1,15 @@
+package loan
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// FetchByAccount returns the loan of an account with its installment and the payments recorded so far.
+	FetchByAccount(c context.Context, userId int, accountId int) (*models.Loan, error)
+	Create(c context.Context, l *models.Loan) error
+	// Schedule returns the full amortization schedule of the loan of an account on its original terms.
+	Schedule(c context.Context, userId int, accountId int) ([]*models.LoanInstallment, error)
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/loan"
+)
+
+type loanUsecase struct {
+	loanRepo       loan.Repository
+	accountRepo    account.Repository
+	contextTimeout time.Duration
+}
+
+func NewLoanUsecase(l loan.Repository, a account.Repository, timeout time.Duration) loan.Usecase {
+	return &loanUsecase{
+		loanRepo:       l,
+		accountRepo:    a,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *loanUsecase) FetchByAccount(c context.Context, userId int, accountId int) (*models.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.loanRepo.FetchByAccount(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Installment = loan.Installment(res)
+
+	res.Payments, err = u.loanRepo.FetchPayments(ctx, res.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *loanUsecase) Create(c context.Context, l *models.Loan) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if l.Principal <= 0 || l.InterestRate < 0 || l.TermMonths <= 0 || l.PaymentDay < 1 || l.PaymentDay > 31 || l.StartDate.IsZero() {
+		return helpers.ErrBadParamInput
+	}
+
+	acc, err := u.accountRepo.FetchById(ctx, l.UserID, l.AccountID)
+
+	if err != nil {
+		return err
+	}
+
+	if acc.Type != loan.AccountType {
+		return helpers.ErrBadParamInput
+	}
+
+	existing, err := u.loanRepo.FetchByAccount(ctx, l.UserID, l.AccountID)
+
+	if err != nil && err != helpers.ErrNotFound {
+		return err
+	}
+
+	if existing != nil {
+		return helpers.ErrConflict
+	}
+
+	l.OutstandingPrincipal = l.Principal
+	l.Status = "active"
+	l.CreatedAt = time.Now()
+	l.UpdatedAt = time.Now()
+
+	err = u.loanRepo.Store(ctx, l)
+
+	if err != nil {
+		return err
+	}
+
+	l.Installment = loan.Installment(l)
+
+	return nil
+}
+
+func (u *loanUsecase) Schedule(c context.Context, userId int, accountId int) ([]*models.LoanInstallment, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	res, err := u.loanRepo.FetchByAccount(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loan.Schedule(res), nil
+}
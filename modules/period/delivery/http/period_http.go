@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/period"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CloseRequest struct {
+	CloseDate string `json:"closeDate" validate:"required"`
+}
+
+type ReopenRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type PeriodHandler struct {
+	PeriodUsecase period.Usecase
+}
+
+func NewPeriodHandler(e *echo.Echo, pu period.Usecase, middleware *middleware.Middleware) {
+	handler := &PeriodHandler{
+		PeriodUsecase: pu,
+	}
+
+	e.GET("/v1/period-close", handler.FetchAll, middleware.Authorize)
+	e.POST("/v1/period-close", handler.Close, middleware.Authorize)
+	e.POST("/v1/admin/user/:userId/period-close/reopen", handler.Reopen, middleware.Authorize, middleware.Admin)
+}
+
+// ShowPeriodClose godoc
+// @Summary Show List Period Close
+// @Description get every period close of the user, reopened ones included, latest first
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Success 200 {array} models.PeriodClose
+// @Header 200 {string} Token "qwerty"
+// @Router /period-close [get]
+func (h *PeriodHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.PeriodUsecase.FetchAll(ctx, userId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ClosePeriod godoc
+// @Summary Close a Period
+// @Description Lock every transaction dated on or before closeDate (YYYY-MM-DD). The date must be later than the current close and not in the future. Locked transactions can only be corrected with reversing entries
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param period body CloseRequest true "CloseRequest Body"
+// @Success 201 {object} models.PeriodClose
+// @Header 200 {string} Token "qwerty"
+// @Router /period-close [post]
+func (h *PeriodHandler) Close(c echo.Context) error {
+	var req CloseRequest
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	closeDate, err := time.Parse("2006-01-02", req.CloseDate)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "closeDate should be formatted as YYYY-MM-DD",
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	userId := c.Get("userId").(int)
+
+	p := &models.PeriodClose{
+		UserID:    userId,
+		CloseDate: closeDate,
+		ClosedBy:  userId,
+	}
+
+	err = h.PeriodUsecase.Close(ctx, p)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, p)
+}
+
+// ReopenPeriod godoc
+// @Summary Reopen a Period
+// @Description Lift the latest period close of a user. Admin only; the reopen and its reason are recorded in the audit log
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param userId path int true "User id"
+// @Param period body ReopenRequest true "ReopenRequest Body"
+// @Success 200 {object} models.PeriodClose
+// @Header 200 {string} Token "qwerty"
+// @Router /admin/user/{userId}/period-close/reopen [post]
+func (h *PeriodHandler) Reopen(c echo.Context) error {
+	var req ReopenRequest
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.PeriodUsecase.Reopen(ctx, c.Get("userId").(int), userId, req.Reason)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func isRequestValid(m interface{}) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,20 @@
+package period
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	// FetchAll lists every close of the user, reopened ones included, latest first.
+	FetchAll(ctx context.Context, userId int) ([]*models.PeriodClose, error)
+	// FetchLatest returns the close in force, the one with the latest date that has not been reopened.
+	FetchLatest(ctx context.Context, userId int) (*models.PeriodClose, error)
+	// ClosedThrough returns the date up to which the transactions of the user are locked, or the zero time when
+	// no period is closed.
+	ClosedThrough(ctx context.Context, userId int) (time.Time, error)
+	Store(ctx context.Context, p *models.PeriodClose) error
+	Reopen(ctx context.Context, p *models.PeriodClose) error
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	"github.com/arham09/fin-api/modules/period"
+	"github.com/sirupsen/logrus"
+)
+
+const selectPeriodClose = `SELECT id, user_id, close_date, closed_by, reopened_by, reopened_at, reopen_reason, status, created_at, updated_at
+	FROM period_closes`
+
+// queryer is the connection, or a database transaction when a read must see the writes made in it.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type mySqlPeriodRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlPeriodRepository(Conn *sql.DB) period.Repository {
+	return &mySqlPeriodRepository{Conn}
+}
+
+func (m *mySqlPeriodRepository) fetch(ctx context.Context, q queryer, query string, args ...interface{}) ([]*models.PeriodClose, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.PeriodClose, 0)
+
+	for rows.Next() {
+		p := new(models.PeriodClose)
+		reopenedBy := sql.NullInt64{}
+		reopenedAt := sql.NullTime{}
+		reopenReason := sql.NullString{}
+		status := int(0)
+
+		err = rows.Scan(
+			&p.ID,
+			&p.UserID,
+			&p.CloseDate,
+			&p.ClosedBy,
+			&reopenedBy,
+			&reopenedAt,
+			&reopenReason,
+			&status,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if status == 1 {
+			p.State = "closed"
+		} else {
+			p.State = "reopened"
+		}
+
+		p.ReopenedBy = int(reopenedBy.Int64)
+		p.ReopenReason = reopenReason.String
+
+		if reopenedAt.Valid {
+			p.ReopenedAt = &reopenedAt.Time
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlPeriodRepository) FetchAll(ctx context.Context, userId int) ([]*models.PeriodClose, error) {
+	query := selectPeriodClose + ` WHERE user_id = ? ORDER BY close_date DESC, id DESC`
+
+	return m.fetch(ctx, m.Conn, query, userId)
+}
+
+func (m *mySqlPeriodRepository) FetchLatest(ctx context.Context, userId int) (*models.PeriodClose, error) {
+	query := selectPeriodClose + ` WHERE status=1 AND user_id = ? ORDER BY close_date DESC, id DESC LIMIT 1`
+
+	list, err := m.fetch(ctx, m.Conn, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlPeriodRepository) ClosedThrough(ctx context.Context, userId int) (time.Time, error) {
+	query := `SELECT MAX(close_date) FROM period_closes WHERE status=1 AND user_id = ?`
+
+	date := sql.NullTime{}
+
+	err := m.Conn.QueryRowContext(ctx, query, userId).Scan(&date)
+
+	if err != nil {
+		logrus.Error(err)
+		return time.Time{}, err
+	}
+
+	return date.Time, nil
+}
+
+// LockClosedThrough returns the date up to which the transactions of the user are locked, or the zero time when no
+// period is closed. The closes of the user are read through tx in share mode, so that no close or reopen commits
+// before tx ends and the writes checked against the date stay on the side of it they were checked on.
+func LockClosedThrough(ctx context.Context, tx *sql.Tx, userId int) (time.Time, error) {
+	query := `SELECT MAX(close_date) FROM period_closes WHERE status=1 AND user_id = ? LOCK IN SHARE MODE`
+
+	date := sql.NullTime{}
+
+	if err := tx.QueryRowContext(ctx, query, userId).Scan(&date); err != nil {
+		return time.Time{}, err
+	}
+
+	return date.Time, nil
+}
+
+// CheckOpen returns ErrPeriodClosed when any of dates falls on or before the date the transactions of the user
+// are closed through, read with LockClosedThrough.
+func CheckOpen(ctx context.Context, tx *sql.Tx, userId int, dates ...time.Time) error {
+	closed, err := LockClosedThrough(ctx, tx, userId)
+
+	if err != nil {
+		return err
+	}
+
+	if closed.IsZero() {
+		return nil
+	}
+
+	for _, d := range dates {
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+
+		if !day.After(closed) {
+			return helpers.ErrPeriodClosed
+		}
+	}
+
+	return nil
+}
+
+func (m *mySqlPeriodRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlPeriodRepository) Store(ctx context.Context, p *models.PeriodClose) error {
+	query := `INSERT period_closes SET user_id=?, close_date=?, closed_by=?, created_at=?, updated_at=?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, p.UserID, p.CloseDate.Format("2006-01-02"), p.ClosedBy, p.CreatedAt, p.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		p.ID = int(lastID)
+
+		return adr.Write(ctx, tx, p.UserID, p.ClosedBy, audit.EntityPeriodClose, p.ID, "close", nil, p)
+	})
+}
+
+func (m *mySqlPeriodRepository) Reopen(ctx context.Context, p *models.PeriodClose) error {
+	query := `UPDATE period_closes SET status=0, reopened_by=?, reopened_at=?, reopen_reason=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		list, err := m.fetch(ctx, tx, selectPeriodClose+` WHERE status=1 AND user_id = ? AND id = ? FOR UPDATE`, p.UserID, p.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			return helpers.ErrNotFound
+		}
+
+		res, err := tx.ExecContext(ctx, query, p.ReopenedBy, p.ReopenedAt, p.ReopenReason, p.UpdatedAt, p.UserID, p.ID)
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		if affect != 1 {
+			err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+			return err
+		}
+
+		return adr.Write(ctx, tx, p.UserID, p.ReopenedBy, audit.EntityPeriodClose, p.ID, "reopen", list[0], p)
+	})
+}
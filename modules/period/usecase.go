@@ -0,0 +1,17 @@
+package period
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int) ([]*models.PeriodClose, error)
+	// Close locks the transactions dated on or before p.CloseDate. The date must be later than the current close
+	// and cannot be in the future.
+	Close(c context.Context, p *models.PeriodClose) error
+	// Reopen lifts the latest close of the user on behalf of the admin adminId. The change is recorded in the
+	// audit log with the reason given.
+	Reopen(c context.Context, adminId int, userId int, reason string) (*models.PeriodClose, error)
+}
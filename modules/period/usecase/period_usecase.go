@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/period"
+)
+
+type periodUsecase struct {
+	periodRepo     period.Repository
+	contextTimeout time.Duration
+}
+
+func NewPeriodUsecase(p period.Repository, timeout time.Duration) period.Usecase {
+	return &periodUsecase{
+		periodRepo:     p,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *periodUsecase) FetchAll(c context.Context, userId int) ([]*models.PeriodClose, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.periodRepo.FetchAll(ctx, userId)
+}
+
+func (u *periodUsecase) Close(c context.Context, p *models.PeriodClose) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if p.CloseDate.After(time.Now()) {
+		return helpers.ErrBadParamInput
+	}
+
+	closed, err := u.periodRepo.ClosedThrough(ctx, p.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	if !p.CloseDate.After(closed) {
+		return helpers.ErrConflict
+	}
+
+	p.State = "closed"
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = time.Now()
+
+	return u.periodRepo.Store(ctx, p)
+}
+
+func (u *periodUsecase) Reopen(c context.Context, adminId int, userId int, reason string) (*models.PeriodClose, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	existing, err := u.periodRepo.FetchLatest(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := *existing
+	now := time.Now()
+
+	res.State = "reopened"
+	res.ReopenedBy = adminId
+	res.ReopenedAt = &now
+	res.ReopenReason = reason
+	res.UpdatedAt = now
+
+	err = u.periodRepo.Reopen(ctx, &res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
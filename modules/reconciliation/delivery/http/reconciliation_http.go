@@ -0,0 +1,318 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/reconciliation"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ReconciliationRequest struct {
+	StatementDate    string       `json:"statementDate" validate:"required"`
+	StatementBalance models.Money `json:"statementBalance"`
+}
+
+type TickRequest struct {
+	TransactionIDs []int `json:"transactionIds" validate:"required,min=1"`
+	Cleared        *bool `json:"cleared" validate:"required"`
+}
+
+type ReconciliationHandler struct {
+	ReconciliationUsecase reconciliation.Usecase
+}
+
+func NewReconciliationHandler(e *echo.Echo, ru reconciliation.Usecase, middleware *middleware.Middleware) {
+	handler := &ReconciliationHandler{
+		ReconciliationUsecase: ru,
+	}
+
+	e.GET("/v1/account/:id/reconciliation", handler.FetchAll, middleware.Authorize)
+	e.POST("/v1/account/:id/reconciliation", handler.Create, middleware.Authorize)
+	e.GET("/v1/reconciliation/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/reconciliation/:id/tick", handler.Tick, middleware.Authorize)
+	e.POST("/v1/reconciliation/:id/finalize", handler.Finalize, middleware.Authorize)
+	e.DELETE("/v1/reconciliation/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowReconciliation godoc
+// @Summary Show List Reconciliation
+// @Description get the reconciliations of an account, latest statement first
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Success 200 {array} models.Reconciliation
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/reconciliation [get]
+func (h *ReconciliationHandler) FetchAll(c echo.Context) error {
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.ReconciliationUsecase.FetchAll(ctx, c.Get("userId").(int), accountId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateReconciliation godoc
+// @Summary Start a Reconciliation
+// @Description Start reconciling an account against a bank statement ending on statementDate (YYYY-MM-DD). An account has at most one open reconciliation
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Account id"
+// @Param reconciliation body ReconciliationRequest true "ReconciliationRequest Body"
+// @Success 201 {object} models.Reconciliation
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/reconciliation [post]
+func (h *ReconciliationHandler) Create(c echo.Context) error {
+	var req ReconciliationRequest
+
+	accountId, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	statementDate, err := time.Parse("2006-01-02", req.StatementDate)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "statementDate should be formatted as YYYY-MM-DD",
+		})
+	}
+
+	r := &models.Reconciliation{
+		UserID:           c.Get("userId").(int),
+		AccountID:        accountId,
+		StatementDate:    statementDate,
+		StatementBalance: req.StatementBalance,
+	}
+
+	err = h.ReconciliationUsecase.Create(ctx, r)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, r)
+}
+
+// ShowReconciliation godoc
+// @Summary Show a Reconciliation
+// @Description get a reconciliation with its cleared balance, the difference to the statement balance and the transactions it covers
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Reconciliation id"
+// @Success 200 {object} models.Reconciliation
+// @Header 200 {string} Token "qwerty"
+// @Router /reconciliation/{id} [get]
+func (h *ReconciliationHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.ReconciliationUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// TickReconciliation godoc
+// @Summary Tick Transactions
+// @Description Mark transactions of an open reconciliation as cleared, or as uncleared when cleared is false. Transactions reconciled by an earlier statement are rejected with 409
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Reconciliation id"
+// @Param tick body TickRequest true "TickRequest Body"
+// @Success 200 {object} models.Reconciliation
+// @Header 200 {string} Token "qwerty"
+// @Router /reconciliation/{id}/tick [post]
+func (h *ReconciliationHandler) Tick(c echo.Context) error {
+	var req TickRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	res, err := h.ReconciliationUsecase.Tick(ctx, c.Get("userId").(int), id, req.TransactionIDs, *req.Cleared)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// FinalizeReconciliation godoc
+// @Summary Finalize a Reconciliation
+// @Description Lock every cleared transaction of the reconciliation against changes. Only possible once the cleared balance equals the statement balance
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Reconciliation id"
+// @Success 200 {object} models.Reconciliation
+// @Header 200 {string} Token "qwerty"
+// @Router /reconciliation/{id}/finalize [post]
+func (h *ReconciliationHandler) Finalize(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.ReconciliationUsecase.Finalize(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteReconciliation godoc
+// @Summary Delete Reconciliation
+// @Description Abandon an open reconciliation. Transactions keep their cleared state
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Reconciliation id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /reconciliation/{id} [delete]
+func (h *ReconciliationHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.ReconciliationUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func isRequestValid(m interface{}) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict, helpers.ErrReconciled:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrNotBalanced:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,24 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int, accountId int) (res []*models.Reconciliation, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Reconciliation, err error)
+	Store(ctx context.Context, r *models.Reconciliation) error
+	// Delete removes a reconciliation that has not been finalized.
+	Delete(ctx context.Context, userId int, id int) error
+	// ClearedBalance sums the cleared and reconciled transactions of the account dated on or before date.
+	ClearedBalance(ctx context.Context, userId int, accountId int, date time.Time) (models.Money, error)
+	// SetCleared ticks or unticks the transactions of the account in ids that are dated on or before date and
+	// not reconciled yet.
+	SetCleared(ctx context.Context, userId int, accountId int, date time.Time, ids []int, cleared bool) error
+	// Finalize marks every cleared transaction covered by r as reconciled by it and closes r, in one database
+	// transaction.
+	Finalize(ctx context.Context, r *models.Reconciliation) error
+}
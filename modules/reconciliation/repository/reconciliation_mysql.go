@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	"github.com/arham09/fin-api/modules/reconciliation"
+	"github.com/sirupsen/logrus"
+)
+
+const selectReconciliation = `SELECT r.id, r.user_id, r.account_id, r.statement_date, r.statement_balance, r.finalized_at, r.status, r.created_at, r.updated_at
+	FROM reconciliations r`
+
+type mySqlReconciliationRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlReconciliationRepository(Conn *sql.DB) reconciliation.Repository {
+	return &mySqlReconciliationRepository{Conn}
+}
+
+func (m *mySqlReconciliationRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Reconciliation, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Reconciliation, 0)
+
+	for rows.Next() {
+		r := new(models.Reconciliation)
+		finalizedAt := sql.NullTime{}
+		status := int(0)
+
+		err = rows.Scan(
+			&r.ID,
+			&r.UserID,
+			&r.AccountID,
+			&r.StatementDate,
+			&r.StatementBalance,
+			&finalizedAt,
+			&status,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if finalizedAt.Valid {
+			r.FinalizedAt = &finalizedAt.Time
+			r.State = "finalized"
+		} else {
+			r.State = "open"
+		}
+
+		if status == 1 {
+			r.Status = "active"
+		} else {
+			r.Status = "inactive"
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlReconciliationRepository) FetchAll(ctx context.Context, userId int, accountId int) (res []*models.Reconciliation, err error) {
+	query := selectReconciliation + ` WHERE r.status=1 AND r.user_id = ? AND r.account_id = ? ORDER BY r.statement_date DESC, r.id DESC`
+
+	return m.fetch(ctx, query, userId, accountId)
+}
+
+func (m *mySqlReconciliationRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Reconciliation, err error) {
+	query := selectReconciliation + ` WHERE r.status=1 AND r.user_id = ? AND r.id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlReconciliationRepository) Store(ctx context.Context, r *models.Reconciliation) error {
+	query := `INSERT reconciliations SET user_id=?, account_id=?, statement_date=?, statement_balance=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, r.UserID, r.AccountID, r.StatementDate.Format("2006-01-02"), r.StatementBalance, r.CreatedAt, r.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	r.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlReconciliationRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE reconciliations SET status=0 WHERE user_id = ? AND id = ? AND finalized_at IS NULL`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
+
+func (m *mySqlReconciliationRepository) ClearedBalance(ctx context.Context, userId int, accountId int, date time.Time) (models.Money, error) {
+	query := `SELECT COALESCE(SUM(amount_in - amount_out), 0) FROM transactions
+		WHERE status=1 AND user_id = ? AND account_id = ? AND cleared > 0 AND trx_date <= ?`
+
+	var balance models.Money
+
+	err := m.Conn.QueryRowContext(ctx, query, userId, accountId, date.Format("2006-01-02")).Scan(&balance)
+
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (m *mySqlReconciliationRepository) SetCleared(ctx context.Context, userId int, accountId int, date time.Time, ids []int, cleared bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	state, action := 0, "unclear"
+
+	if cleared {
+		state, action = 1, "clear"
+	}
+
+	args := []interface{}{userId, accountId, date.Format("2006-01-02"), state}
+
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	where := fmt.Sprintf(`status=1 AND user_id = ? AND account_id = ? AND cleared < 2 AND trx_date <= ? AND cleared <> ?
+		AND id IN (%s)`, strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","))
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		changed, err := m.lockIds(ctx, tx, where, args...)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET cleared=? WHERE `+where, append([]interface{}{state}, args...)...)
+
+		if err != nil {
+			return err
+		}
+
+		return m.logTransactions(ctx, tx, userId, changed, action, nil)
+	})
+}
+
+// lockIds returns the ids of the transactions matching where, locking them until the surrounding database
+// transaction ends.
+func (m *mySqlReconciliationRepository) lockIds(ctx context.Context, tx *sql.Tx, where string, args ...interface{}) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM transactions WHERE `+where+` FOR UPDATE`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	ids := make([]int, 0)
+
+	for rows.Next() {
+		id := int(0)
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// logTransactions records the change of the cleared state of every transaction in ids.
+func (m *mySqlReconciliationRepository) logTransactions(ctx context.Context, tx *sql.Tx, userId int, ids []int, action string, after interface{}) error {
+	for _, id := range ids {
+		if err := adr.Write(ctx, tx, userId, userId, audit.EntityTransaction, id, action, nil, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *mySqlReconciliationRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlReconciliationRepository) Finalize(ctx context.Context, r *models.Reconciliation) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		where := `status=1 AND user_id = ? AND account_id = ? AND cleared = 1 AND trx_date <= ?`
+		args := []interface{}{r.UserID, r.AccountID, r.StatementDate.Format("2006-01-02")}
+
+		ids, err := m.lockIds(ctx, tx, where, args...)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET cleared=2, reconciliation_id=? WHERE `+where, append([]interface{}{r.ID}, args...)...)
+
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE reconciliations SET finalized_at=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ? AND finalized_at IS NULL`
+
+		res, err := tx.ExecContext(ctx, query, r.FinalizedAt, r.UpdatedAt, r.UserID, r.ID)
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		// Another request finalized or deleted the reconciliation first.
+		if affect != 1 {
+			return helpers.ErrConflict
+		}
+
+		return m.logTransactions(ctx, tx, r.UserID, ids, "reconcile", r)
+	})
+}
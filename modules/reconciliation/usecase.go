@@ -0,0 +1,20 @@
+package reconciliation
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int, accountId int) ([]*models.Reconciliation, error)
+	// FetchById returns the reconciliation with its balances and the transactions it covers: those not
+	// reconciled yet while it is open, and those it reconciled once finalized.
+	FetchById(c context.Context, userId int, id int) (*models.Reconciliation, error)
+	Create(c context.Context, r *models.Reconciliation) error
+	// Tick marks the given transactions as cleared, or as uncleared when cleared is false.
+	Tick(c context.Context, userId int, id int, trxIds []int, cleared bool) (*models.Reconciliation, error)
+	// Finalize locks the cleared transactions once the cleared balance matches the statement balance.
+	Finalize(c context.Context, userId int, id int) (*models.Reconciliation, error)
+	Delete(c context.Context, userId int, id int) error
+}
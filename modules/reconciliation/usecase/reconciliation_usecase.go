@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/reconciliation"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+type reconciliationUsecase struct {
+	reconciliationRepo reconciliation.Repository
+	accountRepo        account.Repository
+	trxRepo            transaction.Repository
+	contextTimeout     time.Duration
+}
+
+func NewReconciliationUsecase(r reconciliation.Repository, a account.Repository, t transaction.Repository, timeout time.Duration) reconciliation.Usecase {
+	return &reconciliationUsecase{
+		reconciliationRepo: r,
+		accountRepo:        a,
+		trxRepo:            t,
+		contextTimeout:     timeout,
+	}
+}
+
+func (u *reconciliationUsecase) FetchAll(c context.Context, userId int, accountId int) ([]*models.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := u.reconciliationRepo.FetchAll(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range list {
+		if err = u.fillBalances(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// fillBalances computes the cleared balance and difference of r. A finalized reconciliation balanced when it
+// was closed, so later reconciliations do not change what it reports.
+func (u *reconciliationUsecase) fillBalances(ctx context.Context, r *models.Reconciliation) error {
+	if r.State == "finalized" {
+		r.ClearedBalance = r.StatementBalance
+		r.Difference = 0
+
+		return nil
+	}
+
+	cleared, err := u.reconciliationRepo.ClearedBalance(ctx, r.UserID, r.AccountID, r.StatementDate)
+
+	if err != nil {
+		return err
+	}
+
+	r.ClearedBalance = cleared
+	r.Difference = r.StatementBalance - cleared
+
+	return nil
+}
+
+// transactions lists the transactions r covers.
+func (u *reconciliationUsecase) transactions(ctx context.Context, r *models.Reconciliation) ([]*models.Transaction, error) {
+	filters := map[string]interface{}{
+		"accountId": r.AccountID,
+		"to":        r.StatementDate,
+	}
+
+	list, _, err := u.trxRepo.FetchAll(ctx, r.UserID, filters, "", 0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.Transaction, 0, len(list))
+
+	for _, trx := range list {
+		if (r.State == "open" && trx.Cleared != "reconciled") || (r.State == "finalized" && trx.ReconciliationID == r.ID) {
+			res = append(res, trx)
+		}
+	}
+
+	return res, nil
+}
+
+func (u *reconciliationUsecase) fetch(ctx context.Context, userId int, id int) (*models.Reconciliation, error) {
+	r, err := u.reconciliationRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.fillBalances(ctx, r); err != nil {
+		return nil, err
+	}
+
+	r.Transactions, err = u.transactions(ctx, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (u *reconciliationUsecase) FetchById(c context.Context, userId int, id int) (*models.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.fetch(ctx, userId, id)
+}
+
+func (u *reconciliationUsecase) Create(c context.Context, r *models.Reconciliation) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.accountRepo.FetchById(ctx, r.UserID, r.AccountID)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := u.reconciliationRepo.FetchAll(ctx, r.UserID, r.AccountID)
+
+	if err != nil {
+		return err
+	}
+
+	// An account is reconciled one statement at a time, each ending no earlier than the last finalized one.
+	for _, e := range existing {
+		if e.State == "open" {
+			return helpers.ErrConflict
+		}
+
+		if r.StatementDate.Before(e.StatementDate) {
+			return helpers.ErrBadParamInput
+		}
+	}
+
+	r.State = "open"
+	r.Status = "active"
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+
+	err = u.reconciliationRepo.Store(ctx, r)
+
+	if err != nil {
+		return err
+	}
+
+	return u.fillBalances(ctx, r)
+}
+
+func (u *reconciliationUsecase) Tick(c context.Context, userId int, id int, trxIds []int, cleared bool) (*models.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	r, err := u.reconciliationRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.State != "open" {
+		return nil, helpers.ErrConflict
+	}
+
+	candidates, err := u.transactions(ctx, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	covered := make(map[int]bool, len(candidates))
+
+	for _, trx := range candidates {
+		covered[trx.ID] = true
+	}
+
+	for _, trxId := range trxIds {
+		if covered[trxId] {
+			continue
+		}
+
+		// A transaction finalized by an earlier statement is locked rather than out of range.
+		if trx, err := u.trxRepo.FetchById(ctx, userId, trxId); err == nil && trx.Account.ID == r.AccountID && trx.Cleared == "reconciled" {
+			return nil, helpers.ErrReconciled
+		}
+
+		return nil, helpers.ErrBadParamInput
+	}
+
+	err = u.reconciliationRepo.SetCleared(ctx, userId, r.AccountID, r.StatementDate, trxIds, cleared)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.fetch(ctx, userId, id)
+}
+
+func (u *reconciliationUsecase) Finalize(c context.Context, userId int, id int) (*models.Reconciliation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	r, err := u.reconciliationRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.State != "open" {
+		return nil, helpers.ErrConflict
+	}
+
+	if err = u.fillBalances(ctx, r); err != nil {
+		return nil, err
+	}
+
+	if r.Difference != 0 {
+		return nil, helpers.ErrNotBalanced
+	}
+
+	now := time.Now()
+	r.FinalizedAt = &now
+	r.UpdatedAt = now
+
+	err = u.reconciliationRepo.Finalize(ctx, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.fetch(ctx, userId, id)
+}
+
+func (u *reconciliationUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	r, err := u.reconciliationRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	if r.State != "open" {
+		return helpers.ErrConflict
+	}
+
+	return u.reconciliationRepo.Delete(ctx, userId, id)
+}
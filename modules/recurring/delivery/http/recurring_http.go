@@ -0,0 +1,307 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/recurring"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type RecurringRequest struct {
+	AccountID      int          `json:"accountId" validate:"required"`
+	CategoryID     int          `json:"categoryId"`
+	Name           string       `json:"name" validate:"required"`
+	Type           string       `json:"type" validate:"required,oneof=in out"`
+	Description    string       `json:"description" validate:"required"`
+	Amount         models.Money `json:"amount" validate:"required"`
+	Frequency      string       `json:"frequency" validate:"required,oneof=daily weekly monthly cron"`
+	Interval       int          `json:"interval"`
+	Cron           string       `json:"cron"`
+	StartDate      string       `json:"startDate" validate:"required"`
+	EndDate        string       `json:"endDate"`
+	MaxOccurrences int          `json:"maxOccurrences"`
+}
+
+type RecurringHandler struct {
+	RecurringUsecase recurring.Usecase
+}
+
+func NewRecurringHandler(e *echo.Echo, ru recurring.Usecase, middleware *middleware.Middleware) {
+	handler := &RecurringHandler{
+		RecurringUsecase: ru,
+	}
+
+	e.GET("/v1/recurring", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/recurring/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/recurring", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/recurring/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/recurring/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowRecurring godoc
+// @Summary Show List Recurring
+// @Description get every recurring transaction template with its next occurrence
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Recurring
+// @Header 200 {string} Token "qwerty"
+// @Router /recurring [get]
+func (h *RecurringHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.RecurringUsecase.FetchAll(ctx, c.Get("userId").(int))
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowRecurring godoc
+// @Summary Show a Recurring
+// @Description get recurring transaction template by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Recurring id"
+// @Success 200 {object} models.Recurring
+// @Header 200 {string} Token "qwerty"
+// @Router /recurring/{id} [get]
+func (h *RecurringHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.RecurringUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateRecurring godoc
+// @Summary Create a Recurring
+// @Description Create a template that posts a transaction daily, weekly, monthly or on a cron rule ("day-of-month month day-of-week"), until an end date or a number of occurrences
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param recurring body RecurringRequest true "RecurringRequest Body"
+// @Success 201 {object} models.Recurring
+// @Header 200 {string} Token "qwerty"
+// @Router /recurring [post]
+func (h *RecurringHandler) Create(c echo.Context) error {
+	var req RecurringRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	r, err := toRecurring(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	r.UserID = c.Get("userId").(int)
+
+	err = h.RecurringUsecase.Create(ctx, r)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, r)
+}
+
+// UpdateRecurring godoc
+// @Summary Update Recurring
+// @Description Update recurring transaction template by ID, occurrences already posted are kept
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Recurring id"
+// @Param recurring body RecurringRequest true "RecurringRequest Body"
+// @Success 200 {object} models.Recurring
+// @Header 200 {string} Token "qwerty"
+// @Router /recurring/{id} [patch]
+func (h *RecurringHandler) Update(c echo.Context) error {
+	var req RecurringRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	r, err := toRecurring(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	r.ID = id
+	r.UserID = c.Get("userId").(int)
+
+	res, err := h.RecurringUsecase.Update(ctx, r)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteRecurring godoc
+// @Summary Delete Recurring
+// @Description Delete recurring transaction template by ID, transactions it already posted are kept
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Recurring id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /recurring/{id} [delete]
+func (h *RecurringHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.RecurringUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toRecurring(req *RecurringRequest) (*models.Recurring, error) {
+	if ok, err := isRequestValid(req); !ok {
+		return nil, err
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := &models.Recurring{
+		Name:           req.Name,
+		Type:           req.Type,
+		Description:    req.Description,
+		Amount:         req.Amount,
+		Frequency:      req.Frequency,
+		Interval:       req.Interval,
+		Cron:           req.Cron,
+		StartDate:      start,
+		MaxOccurrences: req.MaxOccurrences,
+	}
+	r.Account.ID = req.AccountID
+
+	if req.CategoryID != 0 {
+		r.Category = &models.Category{ID: req.CategoryID}
+	}
+
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.EndDate = &end
+	}
+
+	return r, nil
+}
+
+func isRequestValid(m *RecurringRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
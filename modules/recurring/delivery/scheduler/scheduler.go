@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/modules/recurring"
+	"github.com/sirupsen/logrus"
+)
+
+// RecurringScheduler posts the due occurrences of every recurring template in the background.
+type RecurringScheduler struct {
+	RecurringUsecase recurring.Usecase
+	Interval         time.Duration
+}
+
+func NewRecurringScheduler(ru recurring.Usecase, interval time.Duration) *RecurringScheduler {
+	return &RecurringScheduler{
+		RecurringUsecase: ru,
+		Interval:         interval,
+	}
+}
+
+// Start runs once right away, which catches up on occurrences missed while the server was down, and then on
+// every tick until ctx is done.
+func (s *RecurringScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RecurringScheduler) run(ctx context.Context) {
+	posted, err := s.RecurringUsecase.PostDue(ctx, time.Now())
+
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	if posted > 0 {
+		logrus.Infof("recurring scheduler posted %d transactions", posted)
+	}
+}
@@ -0,0 +1,25 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int) (res []*models.Recurring, err error)
+	FetchById(ctx context.Context, userId int, id int) (res *models.Recurring, err error)
+	// FetchDue lists the active templates of every user whose next date is on or before date.
+	FetchDue(ctx context.Context, date time.Time) (res []*models.Recurring, err error)
+	Store(ctx context.Context, r *models.Recurring) error
+	Update(ctx context.Context, r *models.Recurring) error
+	Delete(ctx context.Context, userId int, id int) error
+	// Claim reserves the occurrence of the template on date, returning ErrConflict when it was claimed before.
+	Claim(ctx context.Context, recurringId int, date time.Time) error
+	// Release gives up a claim whose transaction could not be posted.
+	Release(ctx context.Context, recurringId int, date time.Time) error
+	// Complete links the occurrence on date to its transaction and moves the template to r.NextDate. It returns
+	// ErrConflict when the template was already moved past date.
+	Complete(ctx context.Context, r *models.Recurring, date time.Time, trxId int) error
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/recurring"
+	"github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
+)
+
+const errDuplicateEntry = 1062
+
+const selectRecurring = `SELECT r.id, r.user_id, r.account_id, a.name, a.currency, r.category_id, c.name, c.type, r.name, r.type, r.description,
+		r.amount, r.frequency, r.interval_count, r.cron, r.start_date, r.end_date, r.max_occurrences, r.occurrences, r.last_date, r.next_date,
+		r.status, r.created_at, r.updated_at
+		FROM recurrings r JOIN accounts a ON r.account_id=a.id LEFT JOIN categories c ON r.category_id=c.id`
+
+type mySqlRecurringRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlRecurringRepository(Conn *sql.DB) recurring.Repository {
+	return &mySqlRecurringRepository{Conn}
+}
+
+func (m *mySqlRecurringRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Recurring, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Recurring, 0)
+
+	for rows.Next() {
+		r := new(models.Recurring)
+		categoryId := sql.NullInt64{}
+		categoryName := sql.NullString{}
+		categoryType := sql.NullString{}
+		endDate := sql.NullTime{}
+		lastDate := sql.NullTime{}
+		nextDate := sql.NullTime{}
+		status := int(0)
+
+		err = rows.Scan(
+			&r.ID,
+			&r.UserID,
+			&r.Account.ID,
+			&r.Account.Name,
+			&r.Account.Currency,
+			&categoryId,
+			&categoryName,
+			&categoryType,
+			&r.Name,
+			&r.Type,
+			&r.Description,
+			&r.Amount,
+			&r.Frequency,
+			&r.Interval,
+			&r.Cron,
+			&r.StartDate,
+			&endDate,
+			&r.MaxOccurrences,
+			&r.Occurrences,
+			&lastDate,
+			&nextDate,
+			&status,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if categoryId.Valid {
+			r.Category = &models.Category{ID: int(categoryId.Int64), Name: categoryName.String, Type: categoryType.String}
+		}
+
+		if endDate.Valid {
+			r.EndDate = &endDate.Time
+		}
+
+		if lastDate.Valid {
+			r.LastDate = &lastDate.Time
+		}
+
+		if nextDate.Valid {
+			r.NextDate = &nextDate.Time
+		}
+
+		if status == 1 {
+			r.Status = "active"
+		} else {
+			r.Status = "inactive"
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func nullableDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return t.Format("2006-01-02")
+}
+
+func nullableCategory(c *models.Category) sql.NullInt64 {
+	if c == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(c.ID), Valid: true}
+}
+
+func (m *mySqlRecurringRepository) FetchAll(ctx context.Context, userId int) (res []*models.Recurring, err error) {
+	query := selectRecurring + ` WHERE r.status=1 AND r.user_id = ? ORDER BY r.next_date IS NULL, r.next_date, r.id`
+
+	return m.fetch(ctx, query, userId)
+}
+
+func (m *mySqlRecurringRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Recurring, err error) {
+	query := selectRecurring + ` WHERE r.status=1 AND r.user_id = ? AND r.id = ?`
+
+	list, err := m.fetch(ctx, query, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, helpers.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (m *mySqlRecurringRepository) FetchDue(ctx context.Context, date time.Time) (res []*models.Recurring, err error) {
+	query := selectRecurring + ` WHERE r.status=1 AND r.next_date IS NOT NULL AND r.next_date <= ? ORDER BY r.next_date, r.id`
+
+	return m.fetch(ctx, query, date.Format("2006-01-02"))
+}
+
+func (m *mySqlRecurringRepository) Store(ctx context.Context, r *models.Recurring) error {
+	query := `INSERT recurrings SET user_id=?, account_id=?, category_id=?, name=?, type=?, description=?, amount=?, frequency=?, interval_count=?,
+		cron=?, start_date=?, end_date=?, max_occurrences=?, next_date=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, r.UserID, r.Account.ID, nullableCategory(r.Category), r.Name, r.Type, r.Description, r.Amount, r.Frequency, r.Interval,
+		r.Cron, r.StartDate.Format("2006-01-02"), nullableDate(r.EndDate), r.MaxOccurrences, nullableDate(r.NextDate), r.CreatedAt, r.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	r.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlRecurringRepository) Update(ctx context.Context, r *models.Recurring) error {
+	query := `UPDATE recurrings SET account_id=?, category_id=?, name=?, type=?, description=?, amount=?, frequency=?, interval_count=?, cron=?,
+		start_date=?, end_date=?, max_occurrences=?, next_date=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, r.Account.ID, nullableCategory(r.Category), r.Name, r.Type, r.Description, r.Amount, r.Frequency, r.Interval, r.Cron,
+		r.StartDate.Format("2006-01-02"), nullableDate(r.EndDate), r.MaxOccurrences, nullableDate(r.NextDate), r.UpdatedAt, r.UserID, r.ID)
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlRecurringRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE recurrings SET status=0 WHERE user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userId, id)
+
+	return err
+}
+
+func (m *mySqlRecurringRepository) Claim(ctx context.Context, recurringId int, date time.Time) error {
+	query := `INSERT recurring_occurrences SET recurring_id=?, occurrence_date=?, created_at=?`
+
+	_, err := m.Conn.ExecContext(ctx, query, recurringId, date.Format("2006-01-02"), time.Now())
+
+	if me, ok := err.(*mysql.MySQLError); ok && me.Number == errDuplicateEntry {
+		return helpers.ErrConflict
+	}
+
+	return err
+}
+
+func (m *mySqlRecurringRepository) Release(ctx context.Context, recurringId int, date time.Time) error {
+	query := `DELETE FROM recurring_occurrences WHERE recurring_id = ? AND occurrence_date = ? AND transaction_id IS NULL`
+
+	_, err := m.Conn.ExecContext(ctx, query, recurringId, date.Format("2006-01-02"))
+
+	return err
+}
+
+func (m *mySqlRecurringRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlRecurringRepository) Complete(ctx context.Context, r *models.Recurring, date time.Time, trxId int) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if trxId != 0 {
+			query := `UPDATE recurring_occurrences SET transaction_id=? WHERE recurring_id = ? AND occurrence_date = ?`
+
+			_, err := tx.ExecContext(ctx, query, trxId, r.ID, date.Format("2006-01-02"))
+
+			if err != nil {
+				return err
+			}
+		}
+
+		query := `UPDATE recurrings SET occurrences=occurrences+1, last_date=?, next_date=?, updated_at=? WHERE id = ? AND next_date = ?`
+
+		res, err := tx.ExecContext(ctx, query, date.Format("2006-01-02"), nullableDate(r.NextDate), time.Now(), r.ID, date.Format("2006-01-02"))
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		if affect != 1 {
+			return helpers.ErrConflict
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,22 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int) ([]*models.Recurring, error)
+	FetchById(c context.Context, userId int, id int) (*models.Recurring, error)
+	Create(c context.Context, r *models.Recurring) error
+	Update(c context.Context, r *models.Recurring) (*models.Recurring, error)
+	Delete(c context.Context, userId int, id int) error
+	// Project lists the transactions the templates of the user are still to post up to to, dated like their
+	// occurrences. Occurrences already due that the scheduler has not posted yet are included.
+	Project(c context.Context, userId int, to time.Time) ([]*models.Transaction, error)
+	// PostDue posts every occurrence dated on or before date that has not been posted yet and returns how many
+	// transactions were created.
+	PostDue(c context.Context, date time.Time) (int, error)
+}
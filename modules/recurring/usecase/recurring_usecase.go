@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/arham09/fin-api/modules/recurring"
+	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/sirupsen/logrus"
+)
+
+type recurringUsecase struct {
+	recurringRepo  recurring.Repository
+	accountRepo    account.Repository
+	categoryRepo   category.Repository
+	trxUsecase     transaction.Usecase
+	contextTimeout time.Duration
+}
+
+func NewRecurringUsecase(r recurring.Repository, a account.Repository, c category.Repository, t transaction.Usecase, timeout time.Duration) recurring.Usecase {
+	return &recurringUsecase{
+		recurringRepo:  r,
+		accountRepo:    a,
+		categoryRepo:   c,
+		trxUsecase:     t,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *recurringUsecase) validate(ctx context.Context, r *models.Recurring) error {
+	if r.Amount <= 0 || r.StartDate.IsZero() || r.MaxOccurrences < 0 || r.Interval < 0 {
+		return helpers.ErrBadParamInput
+	}
+
+	if r.Type != "in" && r.Type != "out" {
+		return helpers.ErrBadParamInput
+	}
+
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		return helpers.ErrBadParamInput
+	}
+
+	switch r.Frequency {
+	case "daily", "weekly", "monthly":
+		r.Cron = ""
+	case "cron":
+		if _, err := parseCron(r.Cron); err != nil {
+			return helpers.ErrBadParamInput
+		}
+	default:
+		return helpers.ErrBadParamInput
+	}
+
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+
+	acc, err := u.accountRepo.FetchById(ctx, r.UserID, r.Account.ID)
+
+	if err != nil {
+		return err
+	}
+
+	r.Account = *acc
+
+	if r.Category != nil {
+		cat, err := u.categoryRepo.FetchById(ctx, r.UserID, r.Category.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if cat.Type != r.Type {
+			return helpers.ErrBadParamInput
+		}
+
+		r.Category = cat
+	}
+
+	return nil
+}
+
+func (u *recurringUsecase) FetchAll(c context.Context, userId int) ([]*models.Recurring, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.recurringRepo.FetchAll(ctx, userId)
+}
+
+func (u *recurringUsecase) FetchById(c context.Context, userId int, id int) (*models.Recurring, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.recurringRepo.FetchById(ctx, userId, id)
+}
+
+func (u *recurringUsecase) Create(c context.Context, r *models.Recurring) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := u.validate(ctx, r); err != nil {
+		return err
+	}
+
+	r.Occurrences = 0
+	r.LastDate = nil
+	r.NextDate = nextDate(r)
+
+	// A rule that matches no date before its end would never post anything.
+	if r.NextDate == nil {
+		return helpers.ErrBadParamInput
+	}
+
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+
+	return u.recurringRepo.Store(ctx, r)
+}
+
+func (u *recurringUsecase) Update(c context.Context, r *models.Recurring) (*models.Recurring, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	existing, err := u.recurringRepo.FetchById(ctx, r.UserID, r.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validate(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Occurrences already posted are kept; the new rule applies from the day after the last one.
+	r.Occurrences = existing.Occurrences
+	r.LastDate = existing.LastDate
+	r.NextDate = nextDate(r)
+	r.UpdatedAt = time.Now()
+
+	err = u.recurringRepo.Update(ctx, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.recurringRepo.FetchById(ctx, r.UserID, r.ID)
+}
+
+func (u *recurringUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.recurringRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.recurringRepo.Delete(ctx, userId, id)
+}
+
+func (u *recurringUsecase) PostDue(c context.Context, date time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	due, err := u.recurringRepo.FetchDue(ctx, date)
+
+	cancel()
+
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	today := midnight(date)
+
+	for _, r := range due {
+		// Catch up every occurrence missed while the scheduler was not running, oldest first.
+		for r.NextDate != nil && !r.NextDate.After(today) {
+			ok, err := u.post(c, r)
+
+			if err != nil {
+				logrus.Errorf("recurring %d: posting %s: %v", r.ID, r.NextDate.Format("2006-01-02"), err)
+				break
+			}
+
+			if ok {
+				posted++
+			}
+		}
+	}
+
+	return posted, nil
+}
+
+// occurrence returns the transaction r posts on date.
+func occurrence(r *models.Recurring, date time.Time) *models.Transaction {
+	trx := &models.Transaction{
+		UserID:      r.UserID,
+		Name:        r.Name,
+		Type:        r.Type,
+		Description: r.Description,
+		Account:     r.Account,
+		Category:    r.Category,
+		Date:        date,
+	}
+
+	if r.Type == "in" {
+		trx.AmountIn = r.Amount
+	} else {
+		trx.AmountOut = r.Amount
+	}
+
+	return trx
+}
+
+func (u *recurringUsecase) Project(c context.Context, userId int, to time.Time) ([]*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	list, err := u.recurringRepo.FetchAll(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	to = midnight(to)
+	res := make([]*models.Transaction, 0)
+
+	for _, r := range list {
+		// Walk a copy, so that r keeps its own next date.
+		next := *r
+
+		for date := r.NextDate; date != nil && !date.After(to); date = nextDate(&next) {
+			res = append(res, occurrence(r, *date))
+
+			day := *date
+			next.Occurrences++
+			next.LastDate = &day
+		}
+	}
+
+	return res, nil
+}
+
+// post creates the transaction of the occurrence at r.NextDate and moves r to the following one. The occurrence
+// is claimed before the transaction is created, so an occurrence claimed by an earlier run or by another instance
+// is skipped rather than posted again, as is one that can no longer be posted; ok reports whether a transaction
+// was created.
+func (u *recurringUsecase) post(c context.Context, r *models.Recurring) (ok bool, err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	date := *r.NextDate
+
+	err = u.recurringRepo.Claim(ctx, r.ID, date)
+
+	if err != nil && err != helpers.ErrConflict {
+		return false, err
+	}
+
+	trxId := 0
+
+	if err == nil {
+		trx := occurrence(r, date)
+
+		err = u.trxUsecase.Create(ctx, trx)
+
+		switch err {
+		case nil:
+			trxId = trx.ID
+		case helpers.ErrPeriodClosed, helpers.ErrNotFound:
+			// The occurrence falls in a closed period or its account is gone; retrying would fail forever, so it
+			// keeps its claim without a transaction and the template moves on.
+			logrus.Warnf("recurring %d: skipping %s: %v", r.ID, date.Format("2006-01-02"), err)
+		default:
+			if releaseErr := u.recurringRepo.Release(ctx, r.ID, date); releaseErr != nil {
+				logrus.Error(releaseErr)
+			}
+
+			return false, err
+		}
+	}
+
+	r.Occurrences++
+	r.LastDate = &date
+	r.NextDate = nextDate(r)
+
+	err = u.recurringRepo.Complete(ctx, r, date, trxId)
+
+	if err != nil {
+		return false, err
+	}
+
+	return trxId != 0, nil
+}
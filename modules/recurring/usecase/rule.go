@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// cronHorizon bounds the search for the next day matched by a cron rule, so that rules such as "30 2 *" that
+// never match end the template instead of looping forever.
+const cronHorizon = 5 * 366
+
+var errInvalidCron = errors.New("cron should be formatted as \"day-of-month month day-of-week\"")
+
+// cronRule is a cron expression reduced to the day level: day of month (1-31), month (1-12) and day of week
+// (0-7, Sunday is 0 or 7). Like cron, a day matches either restricted day field when both are restricted.
+type cronRule struct {
+	dom    [32]bool
+	month  [13]bool
+	dow    [8]bool
+	anyDom bool
+	anyDow bool
+}
+
+func parseCron(spec string) (*cronRule, error) {
+	fields := strings.Fields(spec)
+
+	if len(fields) != 3 {
+		return nil, errInvalidCron
+	}
+
+	rule := new(cronRule)
+
+	if err := parseField(fields[0], 1, 31, rule.dom[:]); err != nil {
+		return nil, err
+	}
+
+	if err := parseField(fields[1], 1, 12, rule.month[:]); err != nil {
+		return nil, err
+	}
+
+	if err := parseField(fields[2], 0, 7, rule.dow[:]); err != nil {
+		return nil, err
+	}
+
+	rule.anyDom = strings.HasPrefix(fields[0], "*")
+	rule.anyDow = strings.HasPrefix(fields[2], "*")
+
+	return rule, nil
+}
+
+// parseField marks every value allowed by a comma separated list of "*", "n", "a-b" and their "/step" forms.
+func parseField(field string, min int, max int, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+
+			if err != nil || n < 1 {
+				return errInvalidCron
+			}
+
+			step = n
+			part = part[:i]
+		}
+
+		from, to := min, max
+
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+
+			n, err := strconv.Atoi(bounds[0])
+
+			if err != nil {
+				return errInvalidCron
+			}
+
+			from, to = n, n
+
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return errInvalidCron
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+
+		if from < min || to > max || from > to {
+			return errInvalidCron
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+
+	return nil
+}
+
+func (c *cronRule) matches(day time.Time) bool {
+	if !c.month[day.Month()] {
+		return false
+	}
+
+	dom, dow := c.dom[day.Day()], c.dow[day.Weekday()] || (day.Weekday() == time.Sunday && c.dow[7])
+
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// addMonths moves start by n months, keeping its day of month where the target month has it and using the last
+// day of the month otherwise, so a template starting on the 31st posts on Feb 28th.
+func addMonths(start time.Time, n int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	day := start.Day()
+
+	if day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// candidate returns the first date matched by the rule of r that is on or after from, counting daily, weekly
+// and monthly steps from the start date.
+func candidate(r *models.Recurring, from time.Time) *time.Time {
+	start := midnight(r.StartDate)
+
+	if from.Before(start) {
+		from = start
+	}
+
+	var date time.Time
+
+	switch r.Frequency {
+	case "daily", "weekly":
+		step := r.Interval
+
+		if r.Frequency == "weekly" {
+			step *= 7
+		}
+
+		days := int(from.Sub(start).Hours() / 24)
+		n := (days + step - 1) / step
+		date = start.AddDate(0, 0, n*step)
+	case "monthly":
+		months := (from.Year()-start.Year())*12 + int(from.Month()-start.Month())
+		n := months / r.Interval * r.Interval
+
+		for date = addMonths(start, n); date.Before(from); date = addMonths(start, n) {
+			n += r.Interval
+		}
+	case "cron":
+		rule, err := parseCron(r.Cron)
+
+		if err != nil {
+			return nil
+		}
+
+		for i := 0; ; i++ {
+			if i > cronHorizon {
+				return nil
+			}
+
+			date = from.AddDate(0, 0, i)
+
+			if rule.matches(date) {
+				break
+			}
+		}
+	default:
+		return nil
+	}
+
+	return &date
+}
+
+// nextDate returns the date of the occurrence that follows the last posted one, or nil once the template has
+// passed its end date or posted its maximum number of occurrences.
+func nextDate(r *models.Recurring) *time.Time {
+	if r.MaxOccurrences > 0 && r.Occurrences >= r.MaxOccurrences {
+		return nil
+	}
+
+	from := midnight(r.StartDate)
+
+	if r.LastDate != nil {
+		from = midnight(*r.LastDate).AddDate(0, 0, 1)
+	}
+
+	date := candidate(r, from)
+
+	if date == nil || (r.EndDate != nil && date.After(midnight(*r.EndDate))) {
+		return nil
+	}
+
+	return date
+}
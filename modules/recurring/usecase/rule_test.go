@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayPtr(y int, m time.Month, d int) *time.Time {
+	t := day(y, m, d)
+
+	return &t
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  bool
+		dom  []int
+	}{
+		{spec: "* * *"},
+		{spec: "1,15 * *", dom: []int{1, 15}},
+		{spec: "1-3 * *", dom: []int{1, 2, 3}},
+		{spec: "5/10 * *", dom: []int{5, 15, 25}},
+		{spec: "*/10 * *", dom: []int{1, 11, 21, 31}},
+		{spec: "1-10/4 1-12 0-7", dom: []int{1, 5, 9}},
+		{spec: "* *", err: true},
+		{spec: "* * * *", err: true},
+		{spec: "0 * *", err: true},
+		{spec: "32 * *", err: true},
+		{spec: "* 0 *", err: true},
+		{spec: "* 13 *", err: true},
+		{spec: "* * 8", err: true},
+		{spec: "5-1 * *", err: true},
+		{spec: "*/0 * *", err: true},
+		{spec: "a * *", err: true},
+		{spec: "1-x * *", err: true},
+		{spec: "1,,2 * *", err: true},
+	}
+
+	for _, tt := range tests {
+		rule, err := parseCron(tt.spec)
+
+		if (err != nil) != tt.err {
+			t.Errorf("parseCron(%q) error = %v, want error %v", tt.spec, err, tt.err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		want := make(map[int]bool)
+
+		for _, d := range tt.dom {
+			want[d] = true
+		}
+
+		for d := 1; d <= 31; d++ {
+			if tt.dom != nil && rule.dom[d] != want[d] {
+				t.Errorf("parseCron(%q) day %d = %v, want %v", tt.spec, d, rule.dom[d], want[d])
+			}
+		}
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		start time.Time
+		n     int
+		want  time.Time
+	}{
+		{start: day(2026, 1, 31), n: 1, want: day(2026, 2, 28)},
+		{start: day(2026, 1, 31), n: 2, want: day(2026, 3, 31)},
+		{start: day(2026, 1, 31), n: 3, want: day(2026, 4, 30)},
+		{start: day(2024, 1, 31), n: 1, want: day(2024, 2, 29)},
+		{start: day(2026, 11, 30), n: 3, want: day(2027, 2, 28)},
+		{start: day(2026, 1, 15), n: 12, want: day(2027, 1, 15)},
+	}
+
+	for _, tt := range tests {
+		if got := addMonths(tt.start, tt.n); !got.Equal(tt.want) {
+			t.Errorf("addMonths(%s, %d) = %s, want %s", tt.start.Format("2006-01-02"), tt.n, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestNextDate(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *models.Recurring
+		want *time.Time
+	}{
+		{
+			name: "first occurrence is the start date",
+			r:    &models.Recurring{Frequency: "monthly", Interval: 1, StartDate: day(2026, 1, 31)},
+			want: dayPtr(2026, 1, 31),
+		},
+		{
+			name: "every third day",
+			r:    &models.Recurring{Frequency: "daily", Interval: 3, StartDate: day(2026, 1, 1), LastDate: dayPtr(2026, 1, 1)},
+			want: dayPtr(2026, 1, 4),
+		},
+		{
+			name: "every other week",
+			r:    &models.Recurring{Frequency: "weekly", Interval: 2, StartDate: day(2026, 1, 1), LastDate: dayPtr(2026, 1, 15)},
+			want: dayPtr(2026, 1, 29),
+		},
+		{
+			name: "month end falls back to the last day",
+			r:    &models.Recurring{Frequency: "monthly", Interval: 1, StartDate: day(2026, 1, 31), LastDate: dayPtr(2026, 1, 31)},
+			want: dayPtr(2026, 2, 28),
+		},
+		{
+			name: "month end returns to the start day",
+			r:    &models.Recurring{Frequency: "monthly", Interval: 1, StartDate: day(2026, 1, 31), LastDate: dayPtr(2026, 2, 28)},
+			want: dayPtr(2026, 3, 31),
+		},
+		{
+			name: "leap february",
+			r:    &models.Recurring{Frequency: "monthly", Interval: 1, StartDate: day(2024, 1, 31), LastDate: dayPtr(2024, 1, 31)},
+			want: dayPtr(2024, 2, 29),
+		},
+		{
+			name: "quarterly across a year",
+			r:    &models.Recurring{Frequency: "monthly", Interval: 3, StartDate: day(2025, 11, 30), LastDate: dayPtr(2025, 11, 30)},
+			want: dayPtr(2026, 2, 28),
+		},
+		{
+			name: "cron skips months without the day",
+			r:    &models.Recurring{Frequency: "cron", Cron: "31 * *", StartDate: day(2026, 1, 1), LastDate: dayPtr(2026, 1, 31)},
+			want: dayPtr(2026, 3, 31),
+		},
+		{
+			name: "cron list of days",
+			r:    &models.Recurring{Frequency: "cron", Cron: "1,15 * *", StartDate: day(2026, 1, 1), LastDate: dayPtr(2026, 1, 1)},
+			want: dayPtr(2026, 1, 15),
+		},
+		{
+			name: "cron sunday as 0",
+			r:    &models.Recurring{Frequency: "cron", Cron: "* * 0", StartDate: day(2026, 1, 1)},
+			want: dayPtr(2026, 1, 4),
+		},
+		{
+			name: "cron sunday as 7",
+			r:    &models.Recurring{Frequency: "cron", Cron: "* * 7", StartDate: day(2026, 1, 1)},
+			want: dayPtr(2026, 1, 4),
+		},
+		{
+			name: "cron matches either restricted day field",
+			r:    &models.Recurring{Frequency: "cron", Cron: "13 * 5", StartDate: day(2026, 1, 1)},
+			want: dayPtr(2026, 1, 2),
+		},
+		{
+			name: "cron month restriction",
+			r:    &models.Recurring{Frequency: "cron", Cron: "*/10 2 *", StartDate: day(2026, 1, 1)},
+			want: dayPtr(2026, 2, 1),
+		},
+		{
+			name: "cron yearly",
+			r:    &models.Recurring{Frequency: "cron", Cron: "1 1 *", StartDate: day(2026, 1, 1), LastDate: dayPtr(2026, 1, 1)},
+			want: dayPtr(2027, 1, 1),
+		},
+		{
+			name: "cron that never matches",
+			r:    &models.Recurring{Frequency: "cron", Cron: "30 2 *", StartDate: day(2026, 1, 1)},
+		},
+		{
+			name: "invalid cron",
+			r:    &models.Recurring{Frequency: "cron", Cron: "0 * *", StartDate: day(2026, 1, 1)},
+		},
+		{
+			name: "maximum occurrences reached",
+			r:    &models.Recurring{Frequency: "daily", Interval: 1, StartDate: day(2026, 1, 1), MaxOccurrences: 2, Occurrences: 2, LastDate: dayPtr(2026, 1, 2)},
+		},
+		{
+			name: "past the end date",
+			r:    &models.Recurring{Frequency: "weekly", Interval: 1, StartDate: day(2026, 1, 1), EndDate: dayPtr(2026, 1, 14), LastDate: dayPtr(2026, 1, 8)},
+		},
+		{
+			name: "on the end date",
+			r:    &models.Recurring{Frequency: "weekly", Interval: 1, StartDate: day(2026, 1, 1), EndDate: dayPtr(2026, 1, 15), LastDate: dayPtr(2026, 1, 8)},
+			want: dayPtr(2026, 1, 15),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextDate(tt.r)
+
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Errorf("nextDate() = %v, want %v", got, tt.want)
+			case !got.Equal(*tt.want):
+				t.Errorf("nextDate() = %s, want %s", got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
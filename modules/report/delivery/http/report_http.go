@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/modules/report"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportHandler struct {
+	ReportUsecase report.Usecase
+}
+
+func NewReportHandler(e *echo.Echo, ru report.Usecase, middleware *middleware.Middleware) {
+	handler := &ReportHandler{
+		ReportUsecase: ru,
+	}
+
+	e.GET("/v1/reports/net-worth", handler.NetWorth, middleware.Authorize)
+	e.GET("/v1/reports/forecast", handler.Forecast, middleware.Authorize)
+}
+
+// ShowNetWorth godoc
+// @Summary Show Net Worth
+// @Description get the assets, liabilities and net worth of every account at the end of each interval from from to to. Credit, loan, mortgage and other debt accounts are liabilities, the rest assets; loans with terms count their outstanding principal and investment accounts the market value of their holdings. Accounts in the trash count for the days before they were deleted
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param from query string false "first day of the report (YYYY-MM-DD), defaults to a year before to"
+// @Param to query string false "last day of the report (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "day, week, month or year, defaults to month"
+// @Param currency query string false "report currency, defaults to IDR"
+// @Success 200 {object} models.NetWorth
+// @Header 200 {string} Token "qwerty"
+// @Router /reports/net-worth [get]
+func (h *ReportHandler) NetWorth(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var err error
+
+	to := time.Now()
+
+	if param := c.QueryParam("to"); param != "" {
+		to, err = time.Parse("2006-01-02", param)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+	}
+
+	from := to.AddDate(-1, 0, 0)
+
+	if param := c.QueryParam("from"); param != "" {
+		from, err = time.Parse("2006-01-02", param)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+	}
+
+	interval := c.QueryParam("interval")
+
+	if interval == "" {
+		interval = report.IntervalMonth
+	}
+
+	res, err := h.ReportUsecase.NetWorth(ctx, c.Get("userId").(int), from, to, interval, c.QueryParam("currency"))
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowForecast godoc
+// @Summary Show Forecast
+// @Description project the balance of every account for the next days days from the transactions already dated in them, the occurrences of recurring templates and a baseline of the average money taken in and paid out per day over the last 90 days, leaving out transfers, voided or reversed pairs and those posted by recurring templates. negativeOn is the first day an asset account is projected to go below zero
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param days query int false "number of days to project, up to 366, defaults to 30"
+// @Success 200 {array} models.Forecast
+// @Header 200 {string} Token "qwerty"
+// @Router /reports/forecast [get]
+func (h *ReportHandler) Forecast(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	days := 30
+
+	if param := c.QueryParam("days"); param != "" {
+		var err error
+
+		days, err = strconv.Atoi(param)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": helpers.ErrBadParamInput.Error(),
+			})
+		}
+	}
+
+	res, err := h.ReportUsecase.Forecast(ctx, c.Get("userId").(int), time.Now(), days)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrRateNotFound:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
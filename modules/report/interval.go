@@ -0,0 +1,88 @@
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+)
+
+// Intervals a report can be broken down by.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+	IntervalYear  = "year"
+)
+
+// MaxPoints caps how many intervals a single report can span.
+const MaxPoints = 400
+
+// liabilityTypes are the words that mark an account type as money owed rather than owned.
+var liabilityTypes = []string{"credit", "loan", "mortgage", "liabilit", "debt", "payable"}
+
+// IsLiability tells whether accounts of the given type hold money owed, like credit cards and loans. Any other
+// type is an asset.
+func IsLiability(accountType string) bool {
+	kind := strings.ToLower(accountType)
+
+	for _, word := range liabilityTypes {
+		if strings.Contains(kind, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// intervalEnd returns the last day of the interval containing day. Weeks end on Sunday.
+func intervalEnd(day time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return day.AddDate(0, 0, (7-int(day.Weekday()))%7)
+	case IntervalMonth:
+		return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	case IntervalYear:
+		return time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Dates returns the last day of every interval from from to to, ending with to itself when it falls inside an
+// interval. It fails with ErrBadParamInput for an unknown interval, a range that ends before it starts or one
+// spanning more than MaxPoints intervals.
+func Dates(from time.Time, to time.Time, interval string) ([]time.Time, error) {
+	switch interval {
+	case IntervalDay, IntervalWeek, IntervalMonth, IntervalYear:
+	default:
+		return nil, helpers.ErrBadParamInput
+	}
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	if to.Before(from) {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	res := make([]time.Time, 0)
+
+	for day := from; ; day = day.AddDate(0, 0, 1) {
+		if len(res) == MaxPoints {
+			return nil, helpers.ErrBadParamInput
+		}
+
+		end := intervalEnd(day, interval)
+
+		if !end.Before(to) {
+			res = append(res, to)
+			break
+		}
+
+		res = append(res, end)
+		day = end
+	}
+
+	return res, nil
+}
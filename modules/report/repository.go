@@ -0,0 +1,20 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	// FetchAccounts returns every account of the user, those in the trash included.
+	FetchAccounts(ctx context.Context, userId int) ([]*models.Account, error)
+	// FetchDailyTotals sums the transactions dated on or before date by account and day. Transactions in the trash
+	// are left out, except those that went there with an account: they are summed apart and carry the day they
+	// were deleted in DeletedAt, as they still counted before it.
+	FetchDailyTotals(ctx context.Context, userId int, date time.Time) ([]*models.Transaction, error)
+	// FetchRecurringPosted returns the ids of the transactions posted by the active recurring templates of the user
+	// for occurrences dated on or after date.
+	FetchRecurringPosted(ctx context.Context, userId int, date time.Time) ([]int, error)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/report"
+	"github.com/sirupsen/logrus"
+)
+
+type mySqlReportRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlReportRepository(Conn *sql.DB) report.Repository {
+	return &mySqlReportRepository{Conn}
+}
+
+func (m *mySqlReportRepository) FetchAccounts(ctx context.Context, userId int) ([]*models.Account, error) {
+	query := `SELECT id, user_id, name, type, description, currency, balance, status, deleted_at, created_at, updated_at
+		FROM accounts WHERE (status=1 OR deleted_at IS NOT NULL) AND user_id = ? ORDER BY id`
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Account, 0)
+
+	for rows.Next() {
+		t := new(models.Account)
+		status := int(0)
+		deletedAt := sql.NullTime{}
+
+		err = rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.Name,
+			&t.Type,
+			&t.Description,
+			&t.Currency,
+			&t.Balance,
+			&status,
+			&deletedAt,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if status == 1 {
+			t.Status = "active"
+		} else {
+			t.Status = "inactive"
+		}
+
+		if deletedAt.Valid {
+			t.DeletedAt = &deletedAt.Time
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlReportRepository) FetchDailyTotals(ctx context.Context, userId int, date time.Time) ([]*models.Transaction, error) {
+	query := `SELECT t.account_id, t.trx_date, SUM(t.amount_in), SUM(t.amount_out), IF(t.status=1, NULL, DATE(t.deleted_at)) AS deleted_on
+		FROM transactions t
+		WHERE (t.status=1 OR t.deleted_with_account IS NOT NULL) AND t.user_id = ? AND t.trx_date <= ?
+		GROUP BY t.account_id, t.trx_date, deleted_on
+		ORDER BY t.trx_date`
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId, date.Format("2006-01-02"))
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Transaction, 0)
+
+	for rows.Next() {
+		t := new(models.Transaction)
+		deletedOn := sql.NullTime{}
+
+		err = rows.Scan(
+			&t.Account.ID,
+			&t.Date,
+			&t.AmountIn,
+			&t.AmountOut,
+			&deletedOn,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if deletedOn.Valid {
+			t.DeletedAt = &deletedOn.Time
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlReportRepository) FetchRecurringPosted(ctx context.Context, userId int, date time.Time) ([]int, error) {
+	query := `SELECT o.transaction_id FROM recurring_occurrences o JOIN recurrings r ON o.recurring_id=r.id
+		WHERE r.status=1 AND r.user_id = ? AND o.transaction_id IS NOT NULL AND o.occurrence_date >= ?`
+
+	rows, err := m.Conn.QueryContext(ctx, query, userId, date.Format("2006-01-02"))
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]int, 0)
+
+	for rows.Next() {
+		id := int(0)
+
+		if err = rows.Scan(&id); err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,16 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// NetWorth values the accounts of the user in currency at the end of every interval from from to to. Accounts
+	// in the trash count for the days before they were deleted.
+	NetWorth(c context.Context, userId int, from time.Time, to time.Time, interval string, currency string) (*models.NetWorth, error)
+	// Forecast projects the balance of every active account of the user for the days days following date.
+	Forecast(c context.Context, userId int, date time.Time, days int) ([]*models.Forecast, error)
+}
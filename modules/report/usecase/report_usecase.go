@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/exchangerate"
+	"github.com/arham09/fin-api/modules/investment"
+	"github.com/arham09/fin-api/modules/loan"
+	"github.com/arham09/fin-api/modules/recurring"
+	"github.com/arham09/fin-api/modules/report"
+	"github.com/arham09/fin-api/modules/security"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+// baselineDays is how many days of history, the forecast date included, set the daily baseline of a forecast.
+const baselineDays = 90
+
+// maxForecastDays caps how far ahead a forecast goes.
+const maxForecastDays = 366
+
+type reportUsecase struct {
+	reportRepo     report.Repository
+	rateRepo       exchangerate.Repository
+	loanRepo       loan.Repository
+	investmentRepo investment.Repository
+	securityRepo   security.Repository
+	trxRepo        transaction.Repository
+	recurringUc    recurring.Usecase
+	contextTimeout time.Duration
+}
+
+func NewReportUsecase(r report.Repository, er exchangerate.Repository, l loan.Repository, i investment.Repository, s security.Repository, t transaction.Repository, ru recurring.Usecase, timeout time.Duration) report.Usecase {
+	return &reportUsecase{
+		reportRepo:     r,
+		rateRepo:       er,
+		loanRepo:       l,
+		investmentRepo: i,
+		securityRepo:   s,
+		trxRepo:        t,
+		recurringUc:    ru,
+		contextTimeout: timeout,
+	}
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// balances works out the balance left by totals, the daily totals of one account, at the end of every date.
+// Totals deleted with the account only count before the day they were deleted.
+func balances(totals []*models.Transaction, dates []time.Time) []models.Money {
+	res := make([]models.Money, len(dates))
+
+	for i, date := range dates {
+		for _, t := range totals {
+			if t.Date.After(date) {
+				continue
+			}
+
+			if t.DeletedAt != nil && !t.DeletedAt.After(date) {
+				continue
+			}
+
+			res[i] += t.AmountIn - t.AmountOut
+		}
+	}
+
+	return res
+}
+
+// values works out what acc is worth at the end of every date in its own currency, negative when it is owed.
+// Most accounts are worth their balance. A loan with terms is worth minus the principal still outstanding, as its
+// balance only collects the payments, and an investment account adds the market value of its holdings to its cash.
+func (u *reportUsecase) values(ctx context.Context, acc *models.Account, totals []*models.Transaction, dates []time.Time) ([]models.Money, error) {
+	switch acc.Type {
+	case loan.AccountType:
+		l, err := u.loanRepo.FetchByAccount(ctx, acc.UserID, acc.ID)
+
+		if err == helpers.ErrNotFound {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		payments, err := u.loanRepo.FetchPayments(ctx, l.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]models.Money, len(dates))
+
+		for i, date := range dates {
+			if date.Before(l.StartDate) {
+				continue
+			}
+
+			res[i] = -l.Principal
+
+			for _, p := range payments {
+				if !p.Date.After(date) {
+					res[i] += p.Principal
+				}
+			}
+		}
+
+		return res, nil
+	case investment.AccountType:
+		res := balances(totals, dates)
+
+		trades, err := u.investmentRepo.FetchAll(ctx, acc.UserID, acc.ID, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The prices each security needs over the whole range are read once and resolved per date in memory.
+		prices := make(map[int][]*models.SecurityPrice)
+
+		for _, t := range trades {
+			if _, ok := prices[t.Security.ID]; ok || t.Date.After(dates[len(dates)-1]) {
+				continue
+			}
+
+			list, err := u.securityRepo.FetchPriceRange(ctx, t.Security.ID, dates[0], dates[len(dates)-1])
+
+			if err != nil {
+				return nil, err
+			}
+
+			prices[t.Security.ID] = list
+		}
+
+		for i, date := range dates {
+			for id, list := range investment.GroupBySecurity(trades, date) {
+				h, err := investment.Value(list, investment.PriceOn(prices[id], date))
+
+				if err != nil {
+					return nil, err
+				}
+
+				res[i] += h.MarketValue
+			}
+		}
+
+		return res, nil
+	}
+
+	return balances(totals, dates), nil
+}
+
+func (u *reportUsecase) NetWorth(c context.Context, userId int, from time.Time, to time.Time, interval string, currency string) (*models.NetWorth, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if currency == "" {
+		currency = helpers.DefaultCurrency
+	}
+
+	currency = strings.ToUpper(currency)
+
+	if err := helpers.VerifyCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	dates, err := report.Dates(from, to, interval)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := u.reportRepo.FetchAccounts(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := u.reportRepo.FetchDailyTotals(ctx, userId, dates[len(dates)-1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[int][]*models.Transaction)
+
+	for _, t := range totals {
+		byAccount[t.Account.ID] = append(byAccount[t.Account.ID], t)
+	}
+
+	res := &models.NetWorth{
+		Currency: currency,
+		Interval: interval,
+		From:     midnight(from),
+		To:       dates[len(dates)-1],
+		Points:   make([]*models.NetWorthPoint, len(dates)),
+	}
+
+	for i, date := range dates {
+		res.Points[i] = &models.NetWorthPoint{Date: date}
+	}
+
+	converter := exchangerate.NewConverter(u.rateRepo, userId)
+
+	for _, acc := range accounts {
+		values, err := u.values(ctx, acc, byAccount[acc.ID], dates)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var deletedOn time.Time
+
+		if acc.DeletedAt != nil {
+			deletedOn = midnight(*acc.DeletedAt)
+		}
+
+		for i, date := range dates {
+			// An account in the trash is left out from the day it was deleted.
+			if acc.DeletedAt != nil && !deletedOn.After(date) {
+				continue
+			}
+
+			value, err := converter.Convert(ctx, values[i], acc.Currency, currency, date)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if report.IsLiability(acc.Type) {
+				res.Points[i].Liabilities -= value
+			} else {
+				res.Points[i].Assets += value
+			}
+		}
+	}
+
+	for _, p := range res.Points {
+		p.NetWorth = p.Assets - p.Liabilities
+	}
+
+	return res, nil
+}
+
+// baseline works out the daily averages of the money taken in and paid out by each account over the baselineDays
+// days up to date, from the transactions covered by the summaries. Transactions posted by recurring templates are
+// left out, as the forecast adds the occurrences of their templates on their own. Accounts opened during that time
+// are averaged over the days since they were opened.
+func (u *reportUsecase) baseline(ctx context.Context, userId int, accounts []*models.Account, date time.Time) (map[int][2]models.Money, error) {
+	start := date.AddDate(0, 0, 1-baselineDays)
+
+	items, err := u.trxRepo.FetchSummaryItems(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := u.reportRepo.FetchRecurringPosted(ctx, userId, start)
+
+	if err != nil {
+		return nil, err
+	}
+
+	posted := make(map[int]bool, len(ids))
+
+	for _, id := range ids {
+		posted[id] = true
+	}
+
+	sums := make(map[int][2]models.Money)
+
+	for _, item := range items {
+		day := midnight(item.Date)
+
+		if day.Before(start) || day.After(date) || posted[item.ID] {
+			continue
+		}
+
+		sum := sums[item.Account.ID]
+		sum[0] += item.AmountIn
+		sum[1] += item.AmountOut
+		sums[item.Account.ID] = sum
+	}
+
+	res := make(map[int][2]models.Money, len(accounts))
+
+	for _, acc := range accounts {
+		span := int64(baselineDays)
+
+		if opened := midnight(acc.CreatedAt); opened.After(start) && !opened.After(date) {
+			span = int64(date.Sub(opened).Hours()/24) + 1
+		}
+
+		sum := sums[acc.ID]
+		res[acc.ID] = [2]models.Money{sum[0].DivRound(span), sum[1].DivRound(span)}
+	}
+
+	return res, nil
+}
+
+func (u *reportUsecase) Forecast(c context.Context, userId int, date time.Time, days int) ([]*models.Forecast, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if days < 1 || days > maxForecastDays {
+		return nil, helpers.ErrBadParamInput
+	}
+
+	date = midnight(date)
+	end := date.AddDate(0, 0, days)
+
+	list, err := u.reportRepo.FetchAccounts(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*models.Account, 0, len(list))
+	res := make([]*models.Forecast, 0, len(list))
+	byAccount := make(map[int]*models.Forecast, len(list))
+
+	for _, acc := range list {
+		if acc.Status != "active" {
+			continue
+		}
+
+		f := &models.Forecast{
+			Account: *acc,
+			Date:    date,
+			Days:    make([]*models.ForecastDay, days),
+		}
+
+		for i := range f.Days {
+			f.Days[i] = &models.ForecastDay{Date: date.AddDate(0, 0, i+1)}
+		}
+
+		accounts = append(accounts, acc)
+		res = append(res, f)
+		byAccount[acc.ID] = f
+	}
+
+	// index returns the forecast day on which a transaction dated day counts, or nil for the days up to date.
+	index := func(f *models.Forecast, day time.Time) *models.ForecastDay {
+		i := int(midnight(day).Sub(date).Hours()/24) - 1
+
+		if i < 0 {
+			return nil
+		}
+
+		return f.Days[i]
+	}
+
+	totals, err := u.reportRepo.FetchDailyTotals(ctx, userId, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range totals {
+		f := byAccount[t.Account.ID]
+
+		if f == nil || t.DeletedAt != nil {
+			continue
+		}
+
+		if d := index(f, t.Date); d != nil {
+			d.In += t.AmountIn
+			d.Out += t.AmountOut
+		} else {
+			f.Balance += t.AmountIn - t.AmountOut
+		}
+	}
+
+	projected, err := u.recurringUc.Project(ctx, userId, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range projected {
+		f := byAccount[t.Account.ID]
+
+		if f == nil {
+			continue
+		}
+
+		// Occurrences the scheduler has yet to catch up on are expected on the first day.
+		d := index(f, t.Date)
+
+		if d == nil {
+			d = f.Days[0]
+		}
+
+		d.In += t.AmountIn
+		d.Out += t.AmountOut
+	}
+
+	baselines, err := u.baseline(ctx, userId, accounts, date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range res {
+		f.BaselineIn, f.BaselineOut = baselines[f.Account.ID][0], baselines[f.Account.ID][1]
+		balance := f.Balance
+
+		for _, d := range f.Days {
+			d.In += f.BaselineIn
+			d.Out += f.BaselineOut
+			balance += d.In - d.Out
+			d.Balance = balance
+
+			if f.NegativeOn == nil && balance < 0 && !report.IsLiability(f.Account.Type) {
+				negativeOn := d.Date
+				f.NegativeOn = &negativeOn
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,415 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/security"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type SecurityRequest struct {
+	Symbol   string `json:"symbol" validate:"required,max=20"`
+	Name     string `json:"name" validate:"required,max=55"`
+	Currency string `json:"currency"`
+}
+
+type PriceRequest struct {
+	Price models.Money `json:"price" validate:"required"`
+	Date  string       `json:"date" validate:"required"`
+}
+
+type SecurityHandler struct {
+	SecurityUsecase security.Usecase
+}
+
+func NewSecurityHandler(e *echo.Echo, su security.Usecase, middleware *middleware.Middleware) {
+	handler := &SecurityHandler{
+		SecurityUsecase: su,
+	}
+
+	e.GET("/v1/security", handler.FetchAll, middleware.Authorize)
+	e.GET("/v1/security/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/security", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/security/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/security/:id", handler.Delete, middleware.Authorize)
+	e.GET("/v1/security/:id/price", handler.FetchPrices, middleware.Authorize)
+	e.POST("/v1/security/:id/price", handler.CreatePrice, middleware.Authorize)
+	e.POST("/v1/security/price/import", handler.ImportPrices, middleware.Authorize)
+}
+
+// ShowSecurity godoc
+// @Summary Show List Security
+// @Description get list security, ordered by symbol
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} models.Security
+// @Header 200 {string} Token "qwerty"
+// @Router /security [get]
+func (h *SecurityHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.SecurityUsecase.FetchAll(ctx, c.Get("userId").(int))
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ShowSecurity godoc
+// @Summary Show a Security
+// @Description get security by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Security id"
+// @Success 200 {object} models.Security
+// @Header 200 {string} Token "qwerty"
+// @Router /security/{id} [get]
+func (h *SecurityHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.SecurityUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateSecurity godoc
+// @Summary Create a Security
+// @Description Create a security traded in investment accounts. The symbol is unique per user and prices are in currency, which defaults to IDR
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param security body SecurityRequest true "SecurityRequest Body"
+// @Success 201 {object} models.Security
+// @Header 200 {string} Token "qwerty"
+// @Router /security [post]
+func (h *SecurityHandler) Create(c echo.Context) error {
+	var req SecurityRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	s := &models.Security{
+		UserID:   c.Get("userId").(int),
+		Symbol:   req.Symbol,
+		Name:     req.Name,
+		Currency: req.Currency,
+	}
+
+	err = h.SecurityUsecase.Create(ctx, s)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, s)
+}
+
+// UpdateSecurity godoc
+// @Summary Update Security
+// @Description Update security by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Security id"
+// @Param security body SecurityRequest true "SecurityRequest Body"
+// @Success 200 {object} models.Security
+// @Header 200 {string} Token "qwerty"
+// @Router /security/{id} [patch]
+func (h *SecurityHandler) Update(c echo.Context) error {
+	var req SecurityRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	s := &models.Security{
+		ID:       id,
+		UserID:   c.Get("userId").(int),
+		Symbol:   req.Symbol,
+		Name:     req.Name,
+		Currency: req.Currency,
+	}
+
+	res, err := h.SecurityUsecase.Update(ctx, s)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteSecurity godoc
+// @Summary Delete Security
+// @Description Delete security by ID. A security that still has trades cannot be deleted
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Security id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /security/{id} [delete]
+func (h *SecurityHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = h.SecurityUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ShowSecurityPrice godoc
+// @Summary Show List Security Price
+// @Description get the prices posted for a security, newest first
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Security id"
+// @Success 200 {array} models.SecurityPrice
+// @Header 200 {string} Token "qwerty"
+// @Router /security/{id}/price [get]
+func (h *SecurityHandler) FetchPrices(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.SecurityUsecase.FetchPrices(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateSecurityPrice godoc
+// @Summary Post a Security Price
+// @Description Store the price of one unit of a security on date (YYYY-MM-DD), replacing the price already posted for that date
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Security id"
+// @Param price body PriceRequest true "PriceRequest Body"
+// @Success 201 {object} models.SecurityPrice
+// @Header 200 {string} Token "qwerty"
+// @Router /security/{id}/price [post]
+func (h *SecurityHandler) CreatePrice(c echo.Context) error {
+	var req PriceRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "date should be formatted as YYYY-MM-DD",
+		})
+	}
+
+	p := &models.SecurityPrice{
+		SecurityID: id,
+		Price:      req.Price,
+		Date:       date,
+	}
+
+	err = h.SecurityUsecase.StorePrice(ctx, c.Get("userId").(int), p)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, p)
+}
+
+// ImportSecurityPrice godoc
+// @Summary Import Security Prices
+// @Description Import prices from a CSV file with date (YYYY-MM-DD), symbol and price columns
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param file formData file true "CSV file"
+// @Success 200 {object} map[string]interface{}
+// @Header 200 {string} Token "qwerty"
+// @Router /security/price/import [post]
+func (h *SecurityHandler) ImportPrices(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "file is missing",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	defer src.Close()
+
+	imported, rowErrors, err := h.SecurityUsecase.ImportPrices(ctx, c.Get("userId").(int), src)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"imported": imported,
+		"errors":   rowErrors,
+	})
+}
+
+func isRequestValid(m interface{}) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,27 @@
+package security
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchAll(ctx context.Context, userId int) ([]*models.Security, error)
+	FetchById(ctx context.Context, userId int, id int) (*models.Security, error)
+	FetchBySymbol(ctx context.Context, userId int, symbol string) (*models.Security, error)
+	Store(ctx context.Context, s *models.Security) error
+	Update(ctx context.Context, s *models.Security) error
+	// Delete fails with ErrConflict while the security still has trades.
+	Delete(ctx context.Context, userId int, id int) error
+	FetchPrices(ctx context.Context, securityId int) ([]*models.SecurityPrice, error)
+	// FetchPrice returns the latest price of the security dated on or before date.
+	FetchPrice(ctx context.Context, securityId int, date time.Time) (*models.SecurityPrice, error)
+	// FetchPriceRange returns, newest first, the prices of the security dated from through to together with the
+	// latest price dated before from, so that the price known on any day of the range can be resolved from them.
+	FetchPriceRange(ctx context.Context, securityId int, from time.Time, to time.Time) ([]*models.SecurityPrice, error)
+	// StorePrices inserts prices in one database transaction, replacing prices already stored for the same
+	// security and date.
+	StorePrices(ctx context.Context, prices []*models.SecurityPrice) error
+}
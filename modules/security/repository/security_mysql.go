@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/security"
+	"github.com/sirupsen/logrus"
+)
+
+const selectSecurity = `SELECT id, user_id, symbol, name, currency, status, created_at, updated_at FROM securities`
+
+// upsertPrice stores a price, replacing the row already kept for the same security and date.
+const upsertPrice = `INSERT INTO security_prices (security_id, price, price_date, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id), price=VALUES(price), updated_at=VALUES(updated_at)`
+
+type mySqlSecurityRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlSecurityRepository(Conn *sql.DB) security.Repository {
+	return &mySqlSecurityRepository{Conn}
+}
+
+func (m *mySqlSecurityRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Security, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Security, 0)
+
+	for rows.Next() {
+		s := new(models.Security)
+		status := int(0)
+
+		err = rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.Symbol,
+			&s.Name,
+			&s.Currency,
+			&status,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		if status == 1 {
+			s.Status = "active"
+		} else {
+			s.Status = "inactive"
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlSecurityRepository) fetchOne(ctx context.Context, query string, args ...interface{}) (*models.Security, error) {
+	list, err := m.fetch(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlSecurityRepository) FetchAll(ctx context.Context, userId int) ([]*models.Security, error) {
+	return m.fetch(ctx, selectSecurity+` WHERE status=1 AND user_id = ? ORDER BY symbol`, userId)
+}
+
+func (m *mySqlSecurityRepository) FetchById(ctx context.Context, userId int, id int) (*models.Security, error) {
+	return m.fetchOne(ctx, selectSecurity+` WHERE status=1 AND user_id = ? AND id = ?`, userId, id)
+}
+
+func (m *mySqlSecurityRepository) FetchBySymbol(ctx context.Context, userId int, symbol string) (*models.Security, error) {
+	return m.fetchOne(ctx, selectSecurity+` WHERE status=1 AND user_id = ? AND symbol = ?`, userId, symbol)
+}
+
+func (m *mySqlSecurityRepository) Store(ctx context.Context, s *models.Security) error {
+	query := `INSERT securities SET user_id=?, symbol=?, name=?, currency=?, created_at=?, updated_at=?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, s.UserID, s.Symbol, s.Name, s.Currency, s.CreatedAt, s.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	s.ID = int(lastID)
+
+	return nil
+}
+
+func (m *mySqlSecurityRepository) Update(ctx context.Context, s *models.Security) error {
+	query := `UPDATE securities SET symbol=?, name=?, currency=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, s.Symbol, s.Name, s.Currency, s.UpdatedAt, s.UserID, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+		return err
+	}
+
+	return nil
+}
+
+func (m *mySqlSecurityRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE securities SET status=0 WHERE status=1 AND user_id = ? AND id = ?
+		AND NOT EXISTS (SELECT 1 FROM trades WHERE status=1 AND security_id = ?)`
+
+	res, err := m.Conn.ExecContext(ctx, query, userId, id, id)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return helpers.ErrConflict
+	}
+
+	return nil
+}
+
+func (m *mySqlSecurityRepository) fetchPrices(ctx context.Context, query string, args ...interface{}) ([]*models.SecurityPrice, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.SecurityPrice, 0)
+
+	for rows.Next() {
+		p := new(models.SecurityPrice)
+
+		err = rows.Scan(
+			&p.ID,
+			&p.SecurityID,
+			&p.Price,
+			&p.Date,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlSecurityRepository) FetchPrices(ctx context.Context, securityId int) ([]*models.SecurityPrice, error) {
+	query := `SELECT id, security_id, price, price_date, created_at, updated_at FROM security_prices
+		WHERE security_id = ? ORDER BY price_date DESC`
+
+	return m.fetchPrices(ctx, query, securityId)
+}
+
+func (m *mySqlSecurityRepository) FetchPrice(ctx context.Context, securityId int, date time.Time) (*models.SecurityPrice, error) {
+	query := `SELECT id, security_id, price, price_date, created_at, updated_at FROM security_prices
+		WHERE security_id = ? AND price_date <= ? ORDER BY price_date DESC LIMIT 1`
+
+	list, err := m.fetchPrices(ctx, query, securityId, date.Format("2006-01-02"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, helpers.ErrNotFound
+	}
+
+	return list[0], nil
+}
+
+func (m *mySqlSecurityRepository) FetchPriceRange(ctx context.Context, securityId int, from time.Time, to time.Time) ([]*models.SecurityPrice, error) {
+	query := `SELECT id, security_id, price, price_date, created_at, updated_at FROM security_prices
+		WHERE security_id = ? AND price_date <= ?
+		AND price_date >= COALESCE((SELECT MAX(p.price_date) FROM security_prices p WHERE p.security_id = ? AND p.price_date <= ?), ?)
+		ORDER BY price_date DESC`
+
+	day := from.Format("2006-01-02")
+
+	return m.fetchPrices(ctx, query, securityId, to.Format("2006-01-02"), securityId, day, day)
+}
+
+func (m *mySqlSecurityRepository) StorePrices(ctx context.Context, prices []*models.SecurityPrice) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range prices {
+		err = storePrice(ctx, tx, p)
+
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logrus.Error(rbErr)
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func storePrice(ctx context.Context, tx *sql.Tx, p *models.SecurityPrice) error {
+	res, err := tx.ExecContext(ctx, upsertPrice, p.SecurityID, p.Price, p.Date.Format("2006-01-02"), p.CreatedAt, p.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return err
+	}
+
+	p.ID = int(lastID)
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package security
+
+import (
+	"context"
+	"io"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int) ([]*models.Security, error)
+	FetchById(c context.Context, userId int, id int) (*models.Security, error)
+	Create(c context.Context, s *models.Security) error
+	Update(c context.Context, s *models.Security) (*models.Security, error)
+	Delete(c context.Context, userId int, id int) error
+	// FetchPrices lists the prices of a security, newest first.
+	FetchPrices(c context.Context, userId int, id int) ([]*models.SecurityPrice, error)
+	// StorePrice stores the price of a security on a date, replacing the price already posted for that date.
+	StorePrice(c context.Context, userId int, p *models.SecurityPrice) error
+	// ImportPrices reads date,symbol,price rows from a CSV file and returns how many were stored along with the
+	// problems found in the rows that were skipped.
+	ImportPrices(c context.Context, userId int, r io.Reader) (int, []*models.ImportError, error)
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/security"
+)
+
+type securityUsecase struct {
+	securityRepo   security.Repository
+	contextTimeout time.Duration
+}
+
+func NewSecurityUsecase(s security.Repository, timeout time.Duration) security.Usecase {
+	return &securityUsecase{
+		securityRepo:   s,
+		contextTimeout: timeout,
+	}
+}
+
+// validate upper-cases the symbol and currency and checks that the symbol is not taken by another security of
+// the user.
+func (u *securityUsecase) validate(ctx context.Context, s *models.Security) error {
+	s.Symbol = strings.ToUpper(strings.TrimSpace(s.Symbol))
+
+	if s.Symbol == "" || s.Name == "" {
+		return helpers.ErrBadParamInput
+	}
+
+	if s.Currency == "" {
+		s.Currency = helpers.DefaultCurrency
+	}
+
+	s.Currency = strings.ToUpper(s.Currency)
+
+	if err := helpers.VerifyCurrency(s.Currency); err != nil {
+		return err
+	}
+
+	existing, err := u.securityRepo.FetchBySymbol(ctx, s.UserID, s.Symbol)
+
+	if err != nil && err != helpers.ErrNotFound {
+		return err
+	}
+
+	if existing != nil && existing.ID != s.ID {
+		return helpers.ErrConflict
+	}
+
+	return nil
+}
+
+func (u *securityUsecase) FetchAll(c context.Context, userId int) ([]*models.Security, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.securityRepo.FetchAll(ctx, userId)
+}
+
+func (u *securityUsecase) FetchById(c context.Context, userId int, id int) (*models.Security, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	return u.securityRepo.FetchById(ctx, userId, id)
+}
+
+func (u *securityUsecase) Create(c context.Context, s *models.Security) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	if err := u.validate(ctx, s); err != nil {
+		return err
+	}
+
+	s.Status = "active"
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+
+	return u.securityRepo.Store(ctx, s)
+}
+
+func (u *securityUsecase) Update(c context.Context, s *models.Security) (*models.Security, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.securityRepo.FetchById(ctx, s.UserID, s.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validate(ctx, s); err != nil {
+		return nil, err
+	}
+
+	s.UpdatedAt = time.Now()
+
+	err = u.securityRepo.Update(ctx, s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.securityRepo.FetchById(ctx, s.UserID, s.ID)
+}
+
+func (u *securityUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.securityRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	return u.securityRepo.Delete(ctx, userId, id)
+}
+
+func (u *securityUsecase) FetchPrices(c context.Context, userId int, id int) ([]*models.SecurityPrice, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.securityRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return u.securityRepo.FetchPrices(ctx, id)
+}
+
+func (u *securityUsecase) StorePrice(c context.Context, userId int, p *models.SecurityPrice) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	_, err := u.securityRepo.FetchById(ctx, userId, p.SecurityID)
+
+	if err != nil {
+		return err
+	}
+
+	if p.Price <= 0 || p.Date.IsZero() {
+		return helpers.ErrBadParamInput
+	}
+
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = time.Now()
+
+	return u.securityRepo.StorePrices(ctx, []*models.SecurityPrice{p})
+}
+
+func (u *securityUsecase) ImportPrices(c context.Context, userId int, r io.Reader) (int, []*models.ImportError, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	prices := make([]*models.SecurityPrice, 0)
+	rowErrors := make([]*models.ImportError, 0)
+	bySymbol := make(map[string]*models.Security)
+	now := time.Now()
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		// The header row is optional.
+		if row == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		p, err := parseRecord(record)
+
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		symbol := strings.ToUpper(strings.TrimSpace(record[1]))
+
+		if bySymbol[symbol] == nil {
+			s, err := u.securityRepo.FetchBySymbol(ctx, userId, symbol)
+
+			if err == helpers.ErrNotFound {
+				rowErrors = append(rowErrors, &models.ImportError{Row: row, Message: fmt.Sprintf("security %s is not found", symbol)})
+				continue
+			}
+
+			if err != nil {
+				return 0, nil, err
+			}
+
+			bySymbol[symbol] = s
+		}
+
+		p.SecurityID = bySymbol[symbol].ID
+		p.CreatedAt = now
+		p.UpdatedAt = now
+
+		prices = append(prices, p)
+	}
+
+	if len(prices) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	err := u.securityRepo.StorePrices(ctx, prices)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(prices), rowErrors, nil
+}
+
+// parseRecord reads the date and price of a date,symbol,price CSV record.
+func parseRecord(record []string) (*models.SecurityPrice, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+
+	if err != nil {
+		return nil, fmt.Errorf("date should be formatted as YYYY-MM-DD")
+	}
+
+	price, err := models.ParseMoney(record[2])
+
+	if err != nil {
+		return nil, err
+	}
+
+	if price <= 0 {
+		return nil, fmt.Errorf("price should be positive")
+	}
+
+	return &models.SecurityPrice{
+		Price: price,
+		Date:  date,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/modules/statement"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type StatementHandler struct {
+	StatementUsecase statement.Usecase
+}
+
+func NewStatementHandler(e *echo.Echo, su statement.Usecase, middleware *middleware.Middleware) {
+	handler := &StatementHandler{
+		StatementUsecase: su,
+	}
+
+	e.GET("/v1/account/:id/statement", handler.FetchStatement, middleware.Authorize)
+}
+
+// ShowStatement godoc
+// @Summary Show a monthly account statement
+// @Description render a printable PDF statement of an account for one month, with the opening balance, every transaction of the month, the closing balance and the in and out totals
+// @Produce  application/pdf
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "account id"
+// @Param month query string true "statement month (YYYY-MM)"
+// @Success 200 {file} file
+// @Header 200 {string} Token "qwerty"
+// @Router /account/{id}/statement [get]
+func (s *StatementHandler) FetchStatement(c echo.Context) error {
+	idAcc, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	month, err := time.Parse("2006-01", c.QueryParam("month"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "month should be formatted as YYYY-MM",
+		})
+	}
+
+	st, err := s.StatementUsecase.Fetch(ctx, userId, idAcc, month)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	var buf bytes.Buffer
+
+	err = s.StatementUsecase.WritePDF(st, &buf)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s.pdf", idAcc, month.Format("2006-01"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+
+	return c.Blob(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,60 @@
+package pdf
+
+// Advance widths of the printable ASCII characters, from space to tilde, in thousandths of the font size, as
+// published in the Adobe font metrics of the standard fonts. Other characters are measured as averageWidth.
+var (
+	helvetica = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBold = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+const averageWidth = 556
+
+// Width returns the width of s in points when written at size.
+func Width(s string, size float64, bold bool) float64 {
+	widths := &helvetica
+
+	if bold {
+		widths = &helveticaBold
+	}
+
+	total := 0
+
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			total += widths[r-32]
+		} else {
+			total += averageWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// Fit shortens s with a trailing ellipsis so that it is at most width points wide.
+func Fit(s string, width float64, size float64, bold bool) string {
+	if Width(s, size, bold) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+
+	for len(runes) > 0 && Width(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "..."
+}
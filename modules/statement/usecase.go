@@ -0,0 +1,16 @@
+package statement
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	// Fetch returns the statement of the account for the calendar month containing month.
+	Fetch(c context.Context, userId int, accountId int, month time.Time) (*models.Statement, error)
+	// WritePDF writes the statement as a printable PDF document.
+	WritePDF(st *models.Statement, w io.Writer) error
+}
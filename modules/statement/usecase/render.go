@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/statement/pdf"
+)
+
+// Horizontal layout of the statement, in points. Amount columns are right aligned on their edge.
+const (
+	left        = 50.0
+	right       = pdf.PageWidth - 50
+	dateX       = left
+	textX       = 105.0
+	textWidth   = 150.0
+	categoryX   = 262.0
+	categoryW   = 75.0
+	inEdge      = 410.0
+	outEdge     = 478.0
+	balanceEdge = right
+	rowHeight   = 14.0
+	bottom      = pdf.PageHeight - 60
+	fontSize    = 8.0
+)
+
+// render lays out a statement: a header with the account and period, the balances and totals, then one table row
+// per transaction between the opening and closing balances.
+func render(st *models.Statement) *pdf.Document {
+	doc := pdf.New()
+	doc.AddPage()
+
+	period := fmt.Sprintf("%s to %s", st.From.Format("02 Jan 2006"), st.To.Format("02 Jan 2006"))
+
+	doc.Text(left, 60, 18, true, "Account Statement")
+	doc.Text(left, 82, 11, true, pdf.Fit(st.Account.Name, 280, 11, true))
+	doc.Text(left, 97, 9, false, pdf.Fit(strings.Join(nonEmpty(st.Account.Type, st.Account.Currency), " - "), 280, 9, false))
+	doc.TextRight(right, 82, 9, true, period)
+	doc.TextRight(right, 97, 9, false, "Generated "+st.GeneratedAt.Format("02 Jan 2006 15:04"))
+
+	doc.Line(left, 110, right, 110, 0.5)
+
+	summary := []struct {
+		label string
+		value models.Money
+	}{
+		{"Opening balance", st.OpeningBalance},
+		{"Total in", st.TotalIn},
+		{"Average in", st.AverageIn},
+		{"Closing balance", st.ClosingBalance},
+		{"Total out", st.TotalOut},
+		{"Average out", st.AverageOut},
+	}
+
+	for i, item := range summary {
+		x := left + float64(i%3)*165
+		y := 128 + float64(i/3)*38
+
+		doc.Text(x, y, fontSize, false, item.label)
+		doc.Text(x, y+15, 12, true, formatAmount(item.value))
+	}
+
+	y := tableHeader(doc, 200)
+
+	row(doc, y, "", "Opening balance", "", "", "", formatAmount(st.OpeningBalance), true)
+	y += rowHeight
+
+	if len(st.Transactions) == 0 {
+		doc.Text(textX, y, fontSize, false, "No transactions in this period.")
+		y += rowHeight
+	}
+
+	for _, trx := range st.Transactions {
+		if y > bottom {
+			doc.AddPage()
+			y = tableHeader(doc, 50)
+		}
+
+		text := trx.Name
+
+		if trx.Description != "" && trx.Description != trx.Name {
+			text = trx.Name + " - " + trx.Description
+		}
+
+		category := ""
+
+		if trx.Category != nil {
+			category = trx.Category.Name
+		} else if len(trx.Splits) > 0 {
+			category = "Split"
+		}
+
+		row(doc, y, trx.Date.Format("2006-01-02"), text, category, optionalAmount(trx.AmountIn), optionalAmount(trx.AmountOut), formatAmount(trx.RunningBalance), false)
+		y += rowHeight
+	}
+
+	if y > bottom {
+		doc.AddPage()
+		y = tableHeader(doc, 50)
+	}
+
+	doc.Line(left, y-10, right, y-10, 0.5)
+	row(doc, y, "", "Closing balance", "", formatAmount(st.TotalIn), formatAmount(st.TotalOut), formatAmount(st.ClosingBalance), true)
+
+	footer := pdf.Fit(fmt.Sprintf("%s - statement for %s", st.Account.Name, period), 380, fontSize, false)
+	pages := doc.PageCount()
+
+	for i := 0; i < pages; i++ {
+		doc.SetPage(i)
+		doc.Text(left, pdf.PageHeight-30, fontSize, false, footer)
+		doc.TextRight(right, pdf.PageHeight-30, fontSize, false, fmt.Sprintf("Page %d of %d", i+1, pages))
+	}
+
+	return doc
+}
+
+// tableHeader draws the column titles with their top at y and returns the baseline of the first row.
+func tableHeader(doc *pdf.Document, y float64) float64 {
+	doc.Rect(left, y, right-left, 16, 0.9)
+	row(doc, y+11, "Date", "Description", "Category", "In", "Out", "Balance", true)
+
+	return y + 30
+}
+
+func row(doc *pdf.Document, y float64, date string, text string, category string, in string, out string, balance string, bold bool) {
+	doc.Text(dateX, y, fontSize, bold, date)
+	doc.Text(textX, y, fontSize, bold, pdf.Fit(text, textWidth, fontSize, bold))
+	doc.Text(categoryX, y, fontSize, bold, pdf.Fit(category, categoryW, fontSize, bold))
+	doc.TextRight(inEdge, y, fontSize, bold, in)
+	doc.TextRight(outEdge, y, fontSize, bold, out)
+	doc.TextRight(balanceEdge, y, fontSize, bold, balance)
+}
+
+func optionalAmount(m models.Money) string {
+	if m == 0 {
+		return ""
+	}
+
+	return formatAmount(m)
+}
+
+// formatAmount groups the whole part of an amount in thousands, as in 1,234,567.50.
+func formatAmount(m models.Money) string {
+	s := m.String()
+	sign := ""
+
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	whole, fraction := s, ""
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i:]
+	}
+
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+
+	return sign + whole + fraction
+}
+
+func nonEmpty(values ...string) []string {
+	res := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/balance"
+	"github.com/arham09/fin-api/modules/statement"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+type statementUsecase struct {
+	accountRepo    account.Repository
+	balanceRepo    balance.Repository
+	trxRepo        transaction.Repository
+	contextTimeout time.Duration
+}
+
+func NewStatementUsecase(a account.Repository, b balance.Repository, t transaction.Repository, timeout time.Duration) statement.Usecase {
+	return &statementUsecase{
+		accountRepo:    a,
+		balanceRepo:    b,
+		trxRepo:        t,
+		contextTimeout: timeout,
+	}
+}
+
+func (s *statementUsecase) Fetch(c context.Context, userId int, accountId int, month time.Time) (*models.Statement, error) {
+	ctx, cancel := context.WithTimeout(c, s.contextTimeout)
+
+	defer cancel()
+
+	acc, err := s.accountRepo.FetchById(ctx, userId, accountId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	opening, err := s.balanceRepo.FetchAsOf(ctx, userId, accountId, from.AddDate(0, 0, -1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	filters := map[string]interface{}{
+		"accountId": accountId,
+		"from":      from,
+		"to":        to,
+	}
+
+	list, _, err := s.trxRepo.FetchAll(ctx, userId, filters, "", 0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	st := &models.Statement{
+		Account:        *acc,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening.Balance,
+		ClosingBalance: opening.Balance,
+		Transactions:   list,
+		GeneratedAt:    time.Now(),
+	}
+
+	var countIn, countOut int64
+
+	for _, trx := range list {
+		st.ClosingBalance += trx.AmountIn - trx.AmountOut
+
+		if !counted(trx) {
+			continue
+		}
+
+		st.TotalIn += trx.AmountIn
+		st.TotalOut += trx.AmountOut
+
+		if trx.AmountIn != 0 {
+			countIn++
+		}
+
+		if trx.AmountOut != 0 {
+			countOut++
+		}
+	}
+
+	if countIn > 0 {
+		st.AverageIn = st.TotalIn.DivRound(countIn)
+	}
+
+	if countOut > 0 {
+		st.AverageOut = st.TotalOut.DivRound(countOut)
+	}
+
+	return st, nil
+}
+
+// counted reports whether trx belongs in the totals and averages, leaving out what the transaction summaries leave
+// out: transfer legs and either side of a void or reversal.
+func counted(trx *models.Transaction) bool {
+	return trx.TransferID == 0 && !trx.Voided && trx.ReversalOf == 0 && trx.ReversedBy == 0
+}
+
+func (s *statementUsecase) WritePDF(st *models.Statement, w io.Writer) error {
+	_, err := render(st).WriteTo(w)
+
+	return err
+}
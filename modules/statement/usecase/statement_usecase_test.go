@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/balance"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+type accountRepo struct {
+	account.Repository
+}
+
+func (r *accountRepo) FetchById(ctx context.Context, userId int, id int) (*models.Account, error) {
+	return &models.Account{ID: id, Name: "Wallet"}, nil
+}
+
+type balanceRepo struct {
+	balance.Repository
+	opening models.Money
+}
+
+func (r *balanceRepo) FetchAsOf(ctx context.Context, userId int, accountId int, date time.Time) (*models.Balance, error) {
+	return &models.Balance{Balance: r.opening}, nil
+}
+
+type trxRepo struct {
+	transaction.Repository
+	list []*models.Transaction
+}
+
+func (r *trxRepo) FetchAll(ctx context.Context, userId int, filters map[string]interface{}, keyword string, limit int, offset int) ([]*models.Transaction, int, error) {
+	return r.list, len(r.list), nil
+}
+
+func TestFetchTotalsMatchSummary(t *testing.T) {
+	list := []*models.Transaction{
+		{ID: 1, AmountIn: 1000000},
+		{ID: 2, AmountOut: 200000},
+		{ID: 3, AmountOut: 400000},
+		{ID: 4, AmountOut: 5000000, TransferID: 1},
+		{ID: 5, AmountIn: 300000, TransferID: 2},
+		{ID: 6, AmountOut: 700000, Voided: true, VoidedBy: 7},
+		{ID: 7, AmountIn: 700000, Voided: true, ReversalOf: 6},
+		{ID: 8, AmountOut: 900000, ReversedBy: 9},
+		{ID: 9, AmountIn: 900000, ReversalOf: 8},
+	}
+
+	uc := NewStatementUsecase(&accountRepo{}, &balanceRepo{opening: 10000000}, &trxRepo{list: list}, time.Second)
+
+	st, err := uc.Fetch(context.Background(), 1, 3, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	want := struct {
+		closing, totalIn, totalOut, averageIn, averageOut models.Money
+	}{
+		closing:    10000000 + 1000000 - 200000 - 400000 - 5000000 + 300000,
+		totalIn:    1000000,
+		totalOut:   600000,
+		averageIn:  1000000,
+		averageOut: 300000,
+	}
+
+	if st.ClosingBalance != want.closing {
+		t.Errorf("closing balance = %s, want %s", st.ClosingBalance, want.closing)
+	}
+
+	if st.TotalIn != want.totalIn || st.TotalOut != want.totalOut {
+		t.Errorf("totals = %s in, %s out, want %s and %s", st.TotalIn, st.TotalOut, want.totalIn, want.totalOut)
+	}
+
+	if st.AverageIn != want.averageIn || st.AverageOut != want.averageOut {
+		t.Errorf("averages = %s in, %s out, want %s and %s", st.AverageIn, st.AverageOut, want.averageIn, want.averageOut)
+	}
+
+	if len(st.Transactions) != len(list) {
+		t.Errorf("statement lists %d transactions, want %d", len(st.Transactions), len(list))
+	}
+}
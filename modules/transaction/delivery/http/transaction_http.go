@@ -2,24 +2,45 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/arham09/fin-api/helpers"
 	"github.com/arham09/fin-api/middleware"
 	"github.com/arham09/fin-api/models"
 	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/arham09/fin-api/modules/transaction/export"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/go-playground/validator.v9"
 )
 
 type TrxRequest struct {
-	Name        string  `json:"name" validate:"required"`
-	Type        string  `json:"type" validate:"required"`
-	Description string  `json:"description" validate:"required"`
-	Amount      float64 `json:"amount" validate:"required"`
-	AccountID   int     `json:"accountId" validate:"required"`
+	Name        string         `json:"name" validate:"required"`
+	Type        string         `json:"type" validate:"required"`
+	Description string         `json:"description" validate:"required"`
+	Amount      models.Money   `json:"amount" validate:"required"`
+	AccountID   int            `json:"accountId" validate:"required"`
+	CategoryID  int            `json:"categoryId"`
+	Date        string         `json:"date"`
+	Splits      []SplitRequest `json:"splits" validate:"dive"`
+}
+
+type SplitRequest struct {
+	Amount     models.Money `json:"amount" validate:"required"`
+	CategoryID int          `json:"categoryId"`
+	Memo       string       `json:"memo"`
+}
+
+type ReverseRequest struct {
+	Date string `json:"date"`
+}
+
+type VoidRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
 }
 
 type TrxHandler struct {
@@ -33,11 +54,15 @@ func NewAccountHandler(e *echo.Echo, tr transaction.Usecase, middleware *middlew
 
 	e.GET("/v1/transaction", handler.FetchAll, middleware.Authorize)
 	e.GET("/v1/transaction/:id", handler.FetchById, middleware.Authorize)
+	e.GET("/v1/transaction/export", handler.Export, middleware.Authorize)
 	e.GET("/v1/transaction/daily", handler.FetchDailySummary, middleware.Authorize)
 	e.GET("/v1/transaction/monthly", handler.FetchMonthlySummary, middleware.Authorize)
 	e.POST("/v1/transaction", handler.Create, middleware.Authorize)
 	e.PATCH("/v1/transaction/:id", handler.Update, middleware.Authorize)
 	e.DELETE("/v1/transaction/:id", handler.Delete, middleware.Authorize)
+	e.POST("/v1/transaction/:id/restore", handler.Restore, middleware.Authorize)
+	e.POST("/v1/transaction/:id/reverse", handler.Reverse, middleware.Authorize)
+	e.POST("/v1/transaction/:id/void", handler.Void, middleware.Authorize)
 }
 
 // ShowTransaction godoc
@@ -46,6 +71,9 @@ func NewAccountHandler(e *echo.Echo, tr transaction.Usecase, middleware *middlew
 // @Param keyword query string false "name search by keyword"
 // @Param type query string false "filter by type"
 // @Param accountId query int64 false "filter by type"
+// @Param categoryId query int64 false "filter by category, including its descendants"
+// @Param from query string false "only transactions dated on or after this day, YYYY-MM-DD"
+// @Param to query string false "only transactions dated on or before this day, YYYY-MM-DD"
 // @Param limit query int true "limit list"
 // @Param offset query int true "offset list"
 // @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
@@ -56,25 +84,13 @@ func NewAccountHandler(e *echo.Echo, tr transaction.Usecase, middleware *middlew
 // @Router /transaction [get]
 func (t *TrxHandler) FetchAll(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	filter := make(map[string]interface{})
-	keyword := ""
-
-	params := c.QueryParams()
-
-	for key, param := range params {
-		if key != "limit" && key != "offset" {
-			if key != "keyword" {
-				filter[key] = param[0]
-			} else {
-				keyword = param[0]
-			}
-		}
-	}
+	filter, keyword := queryFilter(c)
 
 	limit, err := strconv.Atoi(c.QueryParam("limit"))
 
@@ -92,7 +108,7 @@ func (t *TrxHandler) FetchAll(c echo.Context) error {
 		})
 	}
 
-	res, total, err := t.TrxUsecase.FetchAll(ctx, filter, keyword, limit, offset)
+	res, total, err := t.TrxUsecase.FetchAll(ctx, userId, filter, keyword, limit, offset)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -106,6 +122,108 @@ func (t *TrxHandler) FetchAll(c echo.Context) error {
 	})
 }
 
+// queryFilter splits the query parameters of a transaction listing into column filters and the name keyword.
+func queryFilter(c echo.Context) (map[string]interface{}, string) {
+	filter := make(map[string]interface{})
+	keyword := ""
+
+	params := c.QueryParams()
+
+	for key, param := range params {
+		if key != "limit" && key != "offset" && key != "format" {
+			if key != "keyword" {
+				filter[key] = param[0]
+			} else {
+				keyword = param[0]
+			}
+		}
+	}
+
+	return filter, keyword
+}
+
+// ExportTransaction godoc
+// @Summary Export Transactions
+// @Description Download every transaction matching the same filters as the transaction list, with account name, category and running balance columns. Rows are streamed from the database as they are written
+// @Param format query string false "csv (default), jsonl or xlsx"
+// @Param keyword query string false "name search by keyword"
+// @Param type query string false "filter by type"
+// @Param accountId query int64 false "filter by account"
+// @Param categoryId query int64 false "filter by category, including its descendants"
+// @Param from query string false "only transactions dated on or after this day, YYYY-MM-DD"
+// @Param to query string false "only transactions dated on or before this day, YYYY-MM-DD"
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Produce  text/csv
+// @Produce  application/x-ndjson
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/export [get]
+func (t *TrxHandler) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name := c.QueryParam("format")
+
+	if name == "" {
+		name = "csv"
+	}
+
+	format, ok := export.Formats[name]
+
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "format must be csv, jsonl or xlsx",
+		})
+	}
+
+	filter, keyword := queryFilter(c)
+
+	// The response only starts with the first row, so a rejected filter can still be answered with an error.
+	var w export.Writer
+
+	start := func() {
+		filename := fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102"), format.Extension)
+
+		c.Response().Header().Set(echo.HeaderContentType, format.ContentType)
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		c.Response().WriteHeader(http.StatusOK)
+
+		w = format.New(c.Response())
+	}
+
+	err := t.TrxUsecase.Export(ctx, userId, filter, keyword, func(trx *models.Transaction) error {
+		if w == nil {
+			start()
+		}
+
+		return w.Write(trx)
+	})
+
+	if err != nil {
+		if !c.Response().Committed {
+			return c.JSON(getStatusCode(err), map[string]string{
+				"message": err.Error(),
+			})
+		}
+
+		// The client already has part of the file; all that is left is to stop sending it.
+		logrus.Error(err)
+
+		return nil
+	}
+
+	if w == nil {
+		start()
+	}
+
+	return w.Close()
+}
+
 // ShowTransaction godoc
 // @Summary Show a Transaction
 // @Description get string by ID
@@ -119,13 +237,19 @@ func (t *TrxHandler) FetchAll(c echo.Context) error {
 // @Router /transaction/{id} [get]
 func (t *TrxHandler) FetchById(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	user, err := t.TrxUsecase.FetchById(ctx, id)
+	user, err := t.TrxUsecase.FetchById(ctx, userId, id)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -141,17 +265,27 @@ func (t *TrxHandler) FetchById(c echo.Context) error {
 // @Accept  json
 // @Produce  json
 // @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param currency query string false "convert every amount to this reporting currency"
 // @Success 200 {array} models.SummaryDaily
 // @Header 200 {string} Token "qwerty"
 // @Router /transaction/daily [get]
 func (t *TrxHandler) FetchDailySummary(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	data, err := t.TrxUsecase.DailySummary(ctx)
+	currency := strings.ToUpper(c.QueryParam("currency"))
+
+	if currency != "" && helpers.VerifyCurrency(currency) != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "currency should be an ISO 4217 code",
+		})
+	}
+
+	data, err := t.TrxUsecase.DailySummary(ctx, userId, currency)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -167,17 +301,27 @@ func (t *TrxHandler) FetchDailySummary(c echo.Context) error {
 // @Accept  json
 // @Produce  json
 // @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param currency query string false "convert every amount to this reporting currency"
 // @Success 200 {array} models.SummaryMonthly
 // @Header 200 {string} Token "qwerty"
 // @Router /transaction/monthly [get]
 func (t *TrxHandler) FetchMonthlySummary(c echo.Context) error {
 	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	data, err := t.TrxUsecase.MonnthlySummary(ctx)
+	currency := strings.ToUpper(c.QueryParam("currency"))
+
+	if currency != "" && helpers.VerifyCurrency(currency) != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "currency should be an ISO 4217 code",
+		})
+	}
+
+	data, err := t.TrxUsecase.MonnthlySummary(ctx, userId, currency)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -218,11 +362,30 @@ func (t *TrxHandler) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
+	trx.UserID = c.Get("userId").(int)
 	trx.Name = req.Name
 	trx.Type = req.Type
 	trx.Description = req.Description
 	trx.Account.ID = req.AccountID
 
+	if req.CategoryID != 0 {
+		trx.Category = &models.Category{ID: req.CategoryID}
+	}
+
+	trx.Splits = toSplits(req.Splits)
+
+	if req.Date != "" {
+		date, err := time.Parse("2006-01-02", req.Date)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+
+		trx.Date = date
+	}
+
 	if req.Type == "out" {
 		trx.AmountOut = req.Amount
 	} else if req.Type == "in" {
@@ -268,7 +431,7 @@ func (t *TrxHandler) Delete(c echo.Context) error {
 		ctx = context.Background()
 	}
 
-	err = t.TrxUsecase.Delete(ctx, idAcc)
+	err = t.TrxUsecase.Delete(ctx, c.Get("userId").(int), idAcc)
 
 	if err != nil {
 		return c.JSON(getStatusCode(err), map[string]string{
@@ -279,6 +442,139 @@ func (t *TrxHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreTransaction godoc
+// @Summary Restore Transaction
+// @Description Restore a deleted Transaction from the trash; both legs of a transfer are restored together
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Success 200 {object} models.Transaction
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/restore [post]
+func (t *TrxHandler) Restore(c echo.Context) error {
+	idTrx, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := t.TrxUsecase.Restore(ctx, c.Get("userId").(int), idTrx)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ReverseTransaction godoc
+// @Summary Reverse Transaction
+// @Description Post an entry cancelling a Transaction, linked to it through reversalOf while the original names it in reversedBy. The reversal is dated date (YYYY-MM-DD), today by default, which must fall in an open period. Transactions in closed periods can only be corrected this way; a transaction is reversed at most once, and reconciled ones cannot be reversed
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Param reversal body ReverseRequest false "ReverseRequest Body"
+// @Success 201 {object} models.Transaction
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/reverse [post]
+func (t *TrxHandler) Reverse(c echo.Context) error {
+	var req ReverseRequest
+
+	idTrx, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var date time.Time
+
+	if req.Date != "" {
+		date, err = time.Parse("2006-01-02", req.Date)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+	}
+
+	ctx := c.Request().Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := t.TrxUsecase.Reverse(ctx, c.Get("userId").(int), idTrx, date)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// VoidTransaction godoc
+// @Summary Void Transaction
+// @Description Void a Transaction by posting an equal and opposite entry dated today. The original is linked to its reversal through voidedBy and the reversal back through reversalOf; voided pairs are left out of the summaries and cannot be voided twice. Reconciled or reversed transactions cannot be voided
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transaction id"
+// @Param void body VoidRequest true "VoidRequest Body"
+// @Success 201 {object} models.VoidResult
+// @Header 200 {string} Token "qwerty"
+// @Router /transaction/{id}/void [post]
+func (t *TrxHandler) Void(c echo.Context) error {
+	var req VoidRequest
+
+	idTrx, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := t.TrxUsecase.Void(ctx, c.Get("userId").(int), idTrx, req.Reason)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
 // UpdateTransaction godoc
 // @Summary Update Transaction
 // @Description Update Transaction by ID
@@ -317,10 +613,29 @@ func (t *TrxHandler) Update(c echo.Context) error {
 	}
 
 	trx.ID = id
+	trx.UserID = c.Get("userId").(int)
 	trx.Name = req.Name
 	trx.Type = req.Type
 	trx.Description = req.Description
 	trx.Account.ID = req.AccountID
+
+	if req.CategoryID != 0 {
+		trx.Category = &models.Category{ID: req.CategoryID}
+	}
+
+	trx.Splits = toSplits(req.Splits)
+
+	if req.Date != "" {
+		date, err := time.Parse("2006-01-02", req.Date)
+
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "date should be formatted as YYYY-MM-DD",
+			})
+		}
+
+		trx.Date = date
+	}
 	trx.AmountOut = 0
 	trx.AmountIn = 0
 
@@ -345,7 +660,24 @@ func (t *TrxHandler) Update(c echo.Context) error {
 	return c.JSON(http.StatusCreated, res)
 }
 
-func isRequestValid(m *TrxRequest) (bool, error) {
+func toSplits(req []SplitRequest) []*models.TransactionSplit {
+	splits := make([]*models.TransactionSplit, len(req))
+
+	for i, s := range req {
+		splits[i] = &models.TransactionSplit{
+			Amount: s.Amount,
+			Memo:   s.Memo,
+		}
+
+		if s.CategoryID != 0 {
+			splits[i].Category = &models.Category{ID: s.CategoryID}
+		}
+	}
+
+	return splits
+}
+
+func isRequestValid(m interface{}) (bool, error) {
 	validate := validator.New()
 	err := validate.Struct(m)
 	if err != nil {
@@ -364,8 +696,14 @@ func getStatusCode(err error) int {
 		return http.StatusInternalServerError
 	case helpers.ErrNotFound:
 		return http.StatusNotFound
-	case helpers.ErrConflict:
+	case helpers.ErrRateNotFound:
+		return http.StatusUnprocessableEntity
+	case helpers.ErrConflict, helpers.ErrLinkedTransfer, helpers.ErrLinkedTrade, helpers.ErrReconciled, helpers.ErrVoided:
 		return http.StatusConflict
+	case helpers.ErrBadParamInput, helpers.ErrSplitMismatch:
+		return http.StatusBadRequest
+	case helpers.ErrPeriodClosed:
+		return http.StatusLocked
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,147 @@
+// Package export writes transactions as CSV, JSON Lines or XLSX files one row at a time, so exports of any size
+// can be streamed to the client.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// Writer writes transactions in one file format. Close finishes the file and must be called once every
+// transaction has been written.
+type Writer interface {
+	Write(t *models.Transaction) error
+	Close() error
+}
+
+// Format is a file format transactions can be exported to.
+type Format struct {
+	ContentType string
+	Extension   string
+	New         func(w io.Writer) Writer
+}
+
+// Formats lists the supported formats by the name used in the format query parameter.
+var Formats = map[string]Format{
+	"csv":   {"text/csv", "csv", NewCSVWriter},
+	"jsonl": {"application/x-ndjson", "jsonl", NewJSONLWriter},
+	"xlsx":  {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", NewXLSXWriter},
+}
+
+// columns are the header names of the CSV and XLSX exports, in the order of record.
+var columns = []string{"id", "date", "account", "currency", "category", "name", "description", "type", "amountIn", "amountOut", "runningBalance"}
+
+// row is a transaction flattened to the exported columns.
+type row struct {
+	ID             int          `json:"id"`
+	Date           string       `json:"date"`
+	Account        string       `json:"account"`
+	Currency       string       `json:"currency"`
+	Category       string       `json:"category"`
+	Name           string       `json:"name"`
+	Description    string       `json:"description"`
+	Type           string       `json:"type"`
+	AmountIn       models.Money `json:"amountIn"`
+	AmountOut      models.Money `json:"amountOut"`
+	RunningBalance models.Money `json:"runningBalance"`
+}
+
+func toRow(t *models.Transaction) *row {
+	r := &row{
+		ID:             t.ID,
+		Date:           t.Date.Format("2006-01-02"),
+		Account:        t.Account.Name,
+		Currency:       t.Account.Currency,
+		Name:           t.Name,
+		Description:    t.Description,
+		Type:           t.Type,
+		AmountIn:       t.AmountIn,
+		AmountOut:      t.AmountOut,
+		RunningBalance: t.RunningBalance,
+	}
+
+	if t.Category != nil {
+		r.Category = t.Category.Name
+	}
+
+	return r
+}
+
+func (r *row) record() []string {
+	return []string{
+		strconv.Itoa(r.ID),
+		r.Date,
+		r.Account,
+		r.Currency,
+		r.Category,
+		r.Name,
+		r.Description,
+		r.Type,
+		r.AmountIn.String(),
+		r.AmountOut.String(),
+		r.RunningBalance.String(),
+	}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSVWriter returns a Writer producing a CSV file with a header row.
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) writeHeader() error {
+	if cw.header {
+		return nil
+	}
+
+	cw.header = true
+
+	return cw.w.Write(columns)
+}
+
+func (cw *csvWriter) Write(t *models.Transaction) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	return cw.w.Write(toRow(t).record())
+}
+
+func (cw *csvWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+type jsonlWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a Writer producing one JSON object per line.
+func NewJSONLWriter(w io.Writer) Writer {
+	buf := bufio.NewWriter(w)
+
+	return &jsonlWriter{buf, json.NewEncoder(buf)}
+}
+
+func (jw *jsonlWriter) Write(t *models.Transaction) error {
+	return jw.enc.Encode(toRow(t))
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.buf.Flush()
+}
@@ -0,0 +1,160 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+// The fixed parts of a workbook with a single sheet. Cell style 1 shows a date, 2 an amount with two decimals
+// and 3 a bold header.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Transactions" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`},
+}
+
+const (
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// excelEpoch is day zero of the spreadsheet date system.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+	err   error
+}
+
+// NewXLSXWriter returns a Writer producing a spreadsheet with one sheet. Strings are stored inline in the
+// sheet so no part of the workbook has to be held in memory until the end.
+func NewXLSXWriter(w io.Writer) Writer {
+	xw := &xlsxWriter{zip: zip.NewWriter(w)}
+
+	for _, p := range xlsxParts {
+		if xw.err != nil {
+			break
+		}
+
+		var part io.Writer
+
+		part, xw.err = xw.zip.Create(p.name)
+
+		if xw.err == nil {
+			_, xw.err = io.WriteString(part, p.content)
+		}
+	}
+
+	if xw.err == nil {
+		var sheet io.Writer
+
+		sheet, xw.err = xw.zip.Create("xl/worksheets/sheet1.xml")
+		xw.sheet = bufio.NewWriter(sheet)
+	}
+
+	if xw.err == nil {
+		_, xw.err = xw.sheet.WriteString(sheetStart)
+	}
+
+	if xw.err == nil {
+		xw.writeRow(func() {
+			for i, c := range columns {
+				xw.text(i, c, 3)
+			}
+		})
+	}
+
+	return xw
+}
+
+// writeRow writes a row whose cells are written by cells.
+func (xw *xlsxWriter) writeRow(cells func()) {
+	xw.rows++
+	xw.raw(`<row r="` + strconv.Itoa(xw.rows) + `">`)
+	cells()
+	xw.raw(`</row>`)
+}
+
+func (xw *xlsxWriter) raw(s string) {
+	if xw.err == nil {
+		_, xw.err = xw.sheet.WriteString(s)
+	}
+}
+
+func (xw *xlsxWriter) ref(col int) string {
+	return string(rune('A'+col)) + strconv.Itoa(xw.rows)
+}
+
+func (xw *xlsxWriter) text(col int, s string, style int) {
+	xw.raw(`<c r="` + xw.ref(col) + `" t="inlineStr"`)
+
+	if style != 0 {
+		xw.raw(` s="` + strconv.Itoa(style) + `"`)
+	}
+
+	xw.raw(`><is><t xml:space="preserve">`)
+
+	if xw.err == nil {
+		xw.err = xml.EscapeText(xw.sheet, []byte(s))
+	}
+
+	xw.raw(`</t></is></c>`)
+}
+
+func (xw *xlsxWriter) number(col int, v string, style int) {
+	xw.raw(`<c r="` + xw.ref(col) + `" s="` + strconv.Itoa(style) + `"><v>` + v + `</v></c>`)
+}
+
+func (xw *xlsxWriter) Write(t *models.Transaction) error {
+	r := toRow(t)
+	day := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	xw.writeRow(func() {
+		xw.number(0, strconv.Itoa(r.ID), 0)
+		xw.number(1, strconv.Itoa(int(day.Sub(excelEpoch).Hours()/24)), 1)
+		xw.text(2, r.Account, 0)
+		xw.text(3, r.Currency, 0)
+		xw.text(4, r.Category, 0)
+		xw.text(5, r.Name, 0)
+		xw.text(6, r.Description, 0)
+		xw.text(7, r.Type, 0)
+		xw.number(8, r.AmountIn.String(), 2)
+		xw.number(9, r.AmountOut.String(), 2)
+		xw.number(10, r.RunningBalance.String(), 2)
+	})
+
+	return xw.err
+}
+
+func (xw *xlsxWriter) Close() error {
+	xw.raw(sheetEnd)
+
+	if xw.err == nil {
+		xw.err = xw.sheet.Flush()
+	}
+
+	if xw.err != nil {
+		return xw.err
+	}
+
+	return xw.zip.Close()
+}
@@ -2,16 +2,47 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/arham09/fin-api/models"
 )
 
 type Repository interface {
-	FetchAll(ctx context.Context, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Transaction, total int, err error)
-	FetchById(ctx context.Context, id int) (res *models.Transaction, err error)
+	FetchAll(ctx context.Context, userId int, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Transaction, total int, err error)
+	// Stream calls fn for every transaction matching the FetchAll filters, reading rows from the database one at
+	// a time instead of loading them into memory. Splits are not loaded.
+	Stream(ctx context.Context, userId int, filters map[string]interface{}, keyword string, fn func(t *models.Transaction) error) error
+	FetchById(ctx context.Context, userId int, id int) (res *models.Transaction, err error)
+	// Store inserts the transaction. Money coming into an account holding a loan is split into the principal and
+	// interest it pays, replacing its splits, and recorded as a payment of the loan in the same database transaction.
 	Store(ctx context.Context, t *models.Transaction) error
+	// StoreBatch inserts every transaction in list inside one database transaction, splitting loan payments like Store.
+	// Store and StoreBatch fail with ErrPeriodClosed when a transaction falls in a closed period.
+	StoreBatch(ctx context.Context, list []*models.Transaction) error
+	// Update cancels the loan payment the transaction made and records the one it makes once changed.
 	Update(ctx context.Context, a *models.Transaction) error
-	Delete(ctx context.Context, id int) error
-	DailySummary(ctx context.Context) ([]*models.SummaryDaily, error)
-	MonthlySummary(ctx context.Context) ([]*models.SummaryMonthly, error)
+	// Void stores reversal, marked as voided, marks original as voided by it with original.VoidReason and cancels
+	// the loan payment original made if any. It fails with ErrVoided when original has been voided already.
+	Void(ctx context.Context, original *models.Transaction, reversal *models.Transaction) error
+	// Reverse stores reversal, the entry cancelling original, and cancels the loan payment original made if any.
+	Reverse(ctx context.Context, original *models.Transaction, reversal *models.Transaction) error
+	// Delete moves the transaction to the trash and takes it and its loan payment out of the balances. Restore brings
+	// both back.
+	Delete(ctx context.Context, userId int, id int) error
+	// FetchDeleted lists the transactions in the trash that were deleted on their own rather than with their account
+	// or their trade.
+	FetchDeleted(ctx context.Context, userId int) ([]*models.Transaction, error)
+	Restore(ctx context.Context, userId int, id int) error
+	// FetchPurgeable returns the ids and owners of the transactions that went to the trash before the given time and
+	// that no reconciliation, active trade or remaining void or reversal partner still points at.
+	FetchPurgeable(ctx context.Context, before time.Time) ([]*models.Transaction, error)
+	// Purge hard deletes the given transactions from the trash, with their splits, loan payments and emptied
+	// transfers, unlinks them from trades and recurring occurrences, and returns the ids it removed. Rows whose
+	// links changed since FetchPurgeable are left in the trash.
+	Purge(ctx context.Context, ids []int) ([]int, error)
+	DailySummary(ctx context.Context, userId int) ([]*models.SummaryDaily, error)
+	MonthlySummary(ctx context.Context, userId int) ([]*models.SummaryMonthly, error)
+	// FetchSummaryItems returns the ids, accounts, amounts, dates and account currencies of the transactions covered
+	// by the summaries.
+	FetchSummaryItems(ctx context.Context, userId int) ([]*models.Transaction, error)
 }
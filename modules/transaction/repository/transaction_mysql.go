@@ -4,13 +4,39 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/arham09/fin-api/helpers"
 	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	"github.com/arham09/fin-api/modules/loan"
+	pdr "github.com/arham09/fin-api/modules/period/repository"
 	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/go-sql-driver/mysql"
 	"github.com/sirupsen/logrus"
 )
 
+const errDuplicateEntry = 1062
+
+// selectTrx lists the columns scanned by fetch. The running balance sums every active transaction of the same
+// account up to and including the current row, ordered by transaction date. It is computed only for the rows
+// returned, each through a range of idx_transactions_account_date ending at the row's date.
+const selectTrx = `SELECT t.id, t.user_id, t.name, t.type, t.description, t.amount_in, t.amount_out,
+	(SELECT COALESCE(SUM(r.amount_in - r.amount_out), 0) FROM transactions r WHERE r.status=1 AND r.account_id=t.account_id AND r.trx_date <= t.trx_date AND (r.trx_date < t.trx_date OR r.id <= t.id)) AS running_balance,
+	t.status, t.deleted_at, t.transfer_id, t.trade_id, t.fitid, t.cleared, t.reconciliation_id, t.reversal_of, t.reversed_by, t.voided, t.voided_by, t.void_reason, t.account_id, a.name, a.type, a.description, a.currency, a.status, t.category_id, c.name, c.type, t.trx_date, t.created_at, t.updated_at
+	FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id LEFT JOIN categories c ON t.category_id=c.id`
+
+// clearedStates names the values of the cleared column.
+var clearedStates = map[int]string{0: "uncleared", 1: "cleared", 2: "reconciled"}
+
+// queryer is the connection, or a database transaction when a read must see the writes made in it.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 type mySqlTrxRepository struct {
 	Conn *sql.DB
 }
@@ -19,8 +45,8 @@ func NewMysqlTrxRepository(Conn *sql.DB) transaction.Repository {
 	return &mySqlTrxRepository{Conn}
 }
 
-func (m *mySqlTrxRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*models.Transaction, error) {
-	rows, err := m.Conn.QueryContext(ctx, query, args...)
+func (m *mySqlTrxRepository) fetch(ctx context.Context, q queryer, query string, args ...interface{}) ([]*models.Transaction, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		logrus.Error(err)
@@ -37,52 +63,128 @@ func (m *mySqlTrxRepository) fetch(ctx context.Context, query string, args ...in
 	result := make([]*models.Transaction, 0)
 
 	for rows.Next() {
-		t := new(models.Transaction)
-		status := int(0)
-		accountStatus := int(0)
-
-		err = rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Type,
-			&t.Description,
-			&t.AmountIn,
-			&t.AmountOut,
-			&status,
-			&t.Account.ID,
-			&t.Account.Name,
-			&t.Account.Description,
-			&t.Account.Type,
-			&accountStatus,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-		)
+		t, err := scanTrx(rows)
 
 		if err != nil {
-			logrus.Error(err)
 			return nil, err
 		}
 
-		if status == 1 {
-			t.Status = "active"
-		} else {
-			t.Status = "inactive"
-		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// scanTrx reads one row selected with selectTrx.
+func scanTrx(rows *sql.Rows) (*models.Transaction, error) {
+	t := new(models.Transaction)
+	status := int(0)
+	deletedAt := sql.NullTime{}
+	accountStatus := int(0)
+	transferId := sql.NullInt64{}
+	tradeId := sql.NullInt64{}
+	fitId := sql.NullString{}
+	cleared := int(0)
+	reconciliationId := sql.NullInt64{}
+	reversalOf := sql.NullInt64{}
+	reversedBy := sql.NullInt64{}
+	voided := int(0)
+	voidedBy := sql.NullInt64{}
+	voidReason := sql.NullString{}
+	categoryId := sql.NullInt64{}
+	categoryName := sql.NullString{}
+	categoryType := sql.NullString{}
+
+	err := rows.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.Type,
+		&t.Description,
+		&t.AmountIn,
+		&t.AmountOut,
+		&t.RunningBalance,
+		&status,
+		&deletedAt,
+		&transferId,
+		&tradeId,
+		&fitId,
+		&cleared,
+		&reconciliationId,
+		&reversalOf,
+		&reversedBy,
+		&voided,
+		&voidedBy,
+		&voidReason,
+		&t.Account.ID,
+		&t.Account.Name,
+		&t.Account.Description,
+		&t.Account.Type,
+		&t.Account.Currency,
+		&accountStatus,
+		&categoryId,
+		&categoryName,
+		&categoryType,
+		&t.Date,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	if status == 1 {
+		t.Status = "active"
+	} else {
+		t.Status = "inactive"
+	}
+
+	if deletedAt.Valid {
+		t.DeletedAt = &deletedAt.Time
+	}
 
-		if accountStatus == 1 {
-			t.Account.Status = "active"
-		} else {
-			t.Account.Status = "inactive"
+	if transferId.Valid {
+		t.TransferID = int(transferId.Int64)
+	}
+
+	if tradeId.Valid {
+		t.TradeID = int(tradeId.Int64)
+	}
+
+	t.FitID = fitId.String
+	t.Cleared = clearedStates[cleared]
+
+	if reconciliationId.Valid {
+		t.ReconciliationID = int(reconciliationId.Int64)
+	}
+
+	t.ReversalOf = int(reversalOf.Int64)
+	t.ReversedBy = int(reversedBy.Int64)
+	t.Voided = voided == 1
+	t.VoidedBy = int(voidedBy.Int64)
+	t.VoidReason = voidReason.String
+
+	if categoryId.Valid {
+		t.Category = &models.Category{
+			ID:   int(categoryId.Int64),
+			Name: categoryName.String,
+			Type: categoryType.String,
 		}
+	}
 
-		result = append(result, t)
+	if accountStatus == 1 {
+		t.Account.Status = "active"
+	} else {
+		t.Account.Status = "inactive"
 	}
 
-	return result, nil
+	return t, nil
 }
 
-func (m *mySqlTrxRepository) fetchTotal(ctx context.Context, query string) (int, error) {
-	rows, err := m.Conn.QueryContext(ctx, query)
+func (m *mySqlTrxRepository) fetchTotal(ctx context.Context, query string, args ...interface{}) (int, error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		logrus.Error(err)
@@ -116,10 +218,10 @@ func (m *mySqlTrxRepository) fetchTotal(ctx context.Context, query string) (int,
 	return result[0], nil
 }
 
-func (m *mySqlTrxRepository) FetchById(ctx context.Context, id int) (res *models.Transaction, err error) {
-	query := `SELECT t.id, t.name, t.type, t.description, t.amount_in, t.amount_out, t.status, t.account_id, a.name, a.type, a.description, a.status, t.created_at, t.updated_at FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id WHERE t.status=1 AND t.id=?`
+func (m *mySqlTrxRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Transaction, err error) {
+	query := selectTrx + ` WHERE t.status=1 AND t.user_id=? AND t.id=?`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, m.Conn, query, userId, id)
 
 	if err != nil {
 		return nil, err
@@ -131,39 +233,99 @@ func (m *mySqlTrxRepository) FetchById(ctx context.Context, id int) (res *models
 		return nil, helpers.ErrNotFound
 	}
 
+	err = m.fetchSplits(ctx, m.Conn, list)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
-func (m *mySqlTrxRepository) FetchAll(ctx context.Context, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Transaction, total int, err error) {
-	query := `SELECT t.id, t.name, t.type, t.description, t.amount_in, t.amount_out, t.status, t.account_id, a.name, a.type, a.description, a.status, t.created_at, t.updated_at FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id WHERE t.status=1`
-	countQuery := `SELECT COUNT(t.id) AS total FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id WHERE t.status=1`
+// filterColumns maps the filters accepted by FetchAll and Stream that compare a single column to that column.
+var filterColumns = map[string]string{
+	"type":      "t.type",
+	"accountId": "t.account_id",
+}
+
+// filterClause turns the filters and keyword accepted by FetchAll and Stream into conditions on transactions t and
+// their arguments. It fails with ErrBadParamInput on an unknown filter.
+func filterClause(filters map[string]interface{}, keyword string) (string, []interface{}, error) {
+	clause := ""
+	args := make([]interface{}, 0, len(filters)+1)
 
 	for filter, param := range filters {
-		var addFilter string
-
-		if filter == "accountId" {
-			addFilter = fmt.Sprintf(" AND account_id = %v", param)
-		} else {
-			addFilter = fmt.Sprintf(" AND t.%v = \"%v\"", filter, param)
+		switch filter {
+		case "categoryId":
+			ids := param.([]int)
+			marks := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+			clause = clause + fmt.Sprintf(" AND (t.category_id IN (%s) OR EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id=t.id AND s.category_id IN (%s)))", marks, marks)
+
+			for i := 0; i < 2; i++ {
+				for _, id := range ids {
+					args = append(args, id)
+				}
+			}
+		case "from":
+			clause = clause + " AND t.trx_date >= ?"
+			args = append(args, param.(time.Time).Format("2006-01-02"))
+		case "to":
+			clause = clause + " AND t.trx_date <= ?"
+			args = append(args, param.(time.Time).Format("2006-01-02"))
+		default:
+			column, ok := filterColumns[filter]
+
+			if !ok {
+				return "", nil, helpers.ErrBadParamInput
+			}
+
+			clause = clause + fmt.Sprintf(" AND %s = ?", column)
+			args = append(args, param)
 		}
-
-		query = query + addFilter
-		countQuery = countQuery + addFilter
 	}
 
 	if keyword != "" {
-		addSearch := " AND t.name LIKE " + "'%" + keyword + "%'"
-		query = query + addSearch
-		countQuery = countQuery + addSearch
+		clause = clause + " AND t.name LIKE ?"
+		args = append(args, "%"+keyword+"%")
+	}
+
+	return clause, args, nil
+}
+
+func (m *mySqlTrxRepository) FetchAll(ctx context.Context, userId int, filters map[string]interface{}, keyword string, limit int, offset int) (res []*models.Transaction, total int, err error) {
+	query := selectTrx + ` WHERE t.status=1 AND t.user_id=?`
+	countQuery := `SELECT COUNT(t.id) AS total FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id WHERE t.status=1 AND t.user_id=?`
+
+	where, args, err := filterClause(filters, keyword)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	args = append([]interface{}{userId}, args...)
+	query = query + where
+	countQuery = countQuery + where
+
+	query = query + " ORDER BY t.trx_date, t.id"
+
+	// A limit of zero lists every transaction.
+	if limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	}
+
+	totalData, err := m.fetchTotal(ctx, countQuery, args...)
+
+	if err != nil {
+		return nil, 0, err
 	}
 
-	totalData, err := m.fetchTotal(ctx, countQuery)
+	list, err := m.fetch(ctx, m.Conn, query, args...)
 
 	if err != nil {
 		return nil, 0, err
 	}
 
-	list, err := m.fetch(ctx, query)
+	err = m.fetchSplits(ctx, m.Conn, list)
 
 	if err != nil {
 		return nil, 0, err
@@ -172,141 +334,985 @@ func (m *mySqlTrxRepository) FetchAll(ctx context.Context, filters map[string]in
 	return list, totalData, nil
 }
 
-func (m *mySqlTrxRepository) Store(ctx context.Context, t *models.Transaction) error {
-	query := `INSERT transactions SET name=?, account_id=?, type=?, description=?, amount_in=?, amount_out=?, created_at=?, updated_at=?`
-
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+func (m *mySqlTrxRepository) Stream(ctx context.Context, userId int, filters map[string]interface{}, keyword string, fn func(t *models.Transaction) error) error {
+	where, args, err := filterClause(filters, keyword)
 
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, t.Name, t.Account.ID, t.Type, t.Description, t.AmountIn, t.AmountOut, t.CreatedAt, t.UpdatedAt)
+	query := selectTrx + ` WHERE t.status=1 AND t.user_id=?` + where + " ORDER BY t.trx_date, t.id"
+
+	rows, err := m.Conn.QueryContext(ctx, query, append([]interface{}{userId}, args...)...)
 
 	if err != nil {
+		logrus.Error(err)
 		return err
 	}
 
-	lastID, err := res.LastInsertId()
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	for rows.Next() {
+		t, err := scanTrx(rows)
+
+		if err != nil {
+			return err
+		}
+
+		if err = fn(t); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// fetchSplits loads the splits of every transaction in list.
+func (m *mySqlTrxRepository) fetchSplits(ctx context.Context, q queryer, list []*models.Transaction) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*models.Transaction, len(list))
+	ids := make([]int, len(list))
+
+	for i, t := range list {
+		byId[t.ID] = t
+		ids[i] = t.ID
+	}
+
+	query := fmt.Sprintf(`SELECT s.id, s.transaction_id, s.category_id, c.name, c.type, s.amount, s.memo
+		FROM transaction_splits s LEFT JOIN categories c ON s.category_id=c.id WHERE s.transaction_id IN (%s) ORDER BY s.id`, joinIds(ids))
+
+	rows, err := q.QueryContext(ctx, query)
 
 	if err != nil {
+		logrus.Error(err)
 		return err
 	}
 
-	t.ID = int(lastID)
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	for rows.Next() {
+		s := new(models.TransactionSplit)
+		trxId := int(0)
+		categoryId := sql.NullInt64{}
+		categoryName := sql.NullString{}
+		categoryType := sql.NullString{}
+
+		err = rows.Scan(
+			&s.ID,
+			&trxId,
+			&categoryId,
+			&categoryName,
+			&categoryType,
+			&s.Amount,
+			&s.Memo,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		if categoryId.Valid {
+			s.Category = &models.Category{
+				ID:   int(categoryId.Int64),
+				Name: categoryName.String,
+				Type: categoryType.String,
+			}
+		}
+
+		t := byId[trxId]
+		t.Splits = append(t.Splits, s)
+	}
 
 	return nil
 }
 
-func (m *mySqlTrxRepository) Update(ctx context.Context, t *models.Transaction) error {
-	query := `UPDATE transactions SET name=?, account_id=?, type=?, description=?, amount_in=?, amount_out=?, updated_at=? WHERE status=1 AND id = ?`
+// storeSplits replaces the splits of t with t.Splits.
+func (m *mySqlTrxRepository) storeSplits(ctx context.Context, tx *sql.Tx, t *models.Transaction) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM transaction_splits WHERE transaction_id = ?`, t.ID)
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
-		return nil
+		return err
+	}
+
+	query := `INSERT transaction_splits SET transaction_id=?, category_id=?, amount=?, memo=?`
+
+	for _, s := range t.Splits {
+		res, err := tx.ExecContext(ctx, query, t.ID, nullableCategory(s.Category), s.Amount, s.Memo)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		s.ID = int(lastID)
+	}
+
+	return nil
+}
+
+// nullableCategory stores uncategorized transactions with a NULL category.
+func nullableCategory(c *models.Category) sql.NullInt64 {
+	if c == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(c.ID), Valid: true}
+}
+
+func joinIds(ids []int) string {
+	list := make([]string, len(ids))
+
+	for i, id := range ids {
+		list[i] = strconv.Itoa(id)
 	}
 
-	res, err := stmt.ExecContext(ctx, t.Name, t.Account.ID, t.Type, t.Description, t.AmountIn, t.AmountOut, t.UpdatedAt, t.ID)
+	return strings.Join(list, ",")
+}
+
+func (m *mySqlTrxRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
 	if err != nil {
 		return err
 	}
-	affect, err := res.RowsAffected()
+
+	err = fn(tx)
+
 	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
 		return err
 	}
-	if affect != 1 {
-		err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
 
-		return err
+	return tx.Commit()
+}
+
+// adjustBalance applies the net effect of a transaction to the current balance of its account.
+func (m *mySqlTrxRepository) adjustBalance(ctx context.Context, tx *sql.Tx, userId int, accountId int, amountIn models.Money, amountOut models.Money) error {
+	query := `UPDATE accounts SET balance = balance + CAST(? AS DECIMAL(19,4)) - CAST(? AS DECIMAL(19,4)) WHERE user_id = ? AND id = ?`
+
+	_, err := tx.ExecContext(ctx, query, amountIn, amountOut, userId, accountId)
+
+	return err
+}
+
+// payLoan splits t into the principal and interest it pays when it is money coming into an account holding a loan,
+// replacing any splits it was given, and returns the payment to record once t is stored, or nil when t pays no
+// loan. The loan stays locked until the surrounding database transaction ends, so payments made at the same time
+// are split one after the other. Reversals never pay a loan.
+func (m *mySqlTrxRepository) payLoan(ctx context.Context, tx *sql.Tx, t *models.Transaction) (*models.LoanPayment, error) {
+	if t.Type != "in" || t.ReversalOf != 0 {
+		return nil, nil
 	}
 
-	return nil
+	query := `SELECT l.id, l.interest_rate, l.outstanding_principal FROM loans l JOIN accounts a ON l.account_id=a.id
+		WHERE l.status=1 AND l.user_id = ? AND l.account_id = ? AND a.type = ? FOR UPDATE`
+
+	l := new(models.Loan)
+
+	err := tx.QueryRowContext(ctx, query, t.UserID, t.Account.ID, loan.AccountType).Scan(&l.ID, &l.InterestRate, &l.OutstandingPrincipal)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	principal, interest, err := loan.SplitPayment(l, t.AmountIn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.Splits = nil
+
+	if principal > 0 {
+		t.Splits = append(t.Splits, &models.TransactionSplit{Amount: principal, Memo: "Principal"})
+	}
+
+	if interest > 0 {
+		t.Splits = append(t.Splits, &models.TransactionSplit{Amount: interest, Memo: "Interest"})
+	}
+
+	return &models.LoanPayment{
+		LoanID:               l.ID,
+		Date:                 t.Date,
+		Principal:            principal,
+		Interest:             interest,
+		OutstandingPrincipal: l.OutstandingPrincipal - principal,
+	}, nil
 }
 
-func (m *mySqlTrxRepository) Delete(ctx context.Context, id int) error {
-	query := `UPDATE transactions SET status=0 WHERE id = ?`
+// storePayment records p, if any, for the stored transaction t and lowers the outstanding principal of its loan by
+// the principal part.
+func (m *mySqlTrxRepository) storePayment(ctx context.Context, tx *sql.Tx, t *models.Transaction, p *models.LoanPayment) error {
+	if p == nil {
+		return nil
+	}
+
+	query := `INSERT loan_payments SET loan_id=?, transaction_id=?, payment_date=?, principal=?, interest=?,
+		outstanding_principal=?, created_at=?`
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	p.TransactionID = t.ID
+	p.CreatedAt = t.UpdatedAt
+
+	res, err := tx.ExecContext(ctx, query, p.LoanID, p.TransactionID, p.Date.Format("2006-01-02"), p.Principal,
+		p.Interest, p.OutstandingPrincipal, p.CreatedAt)
 
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.ExecContext(ctx, id)
+	lastID, err := res.LastInsertId()
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	p.ID = int(lastID)
+
+	_, err = tx.ExecContext(ctx, `UPDATE loans SET outstanding_principal = outstanding_principal - ?, updated_at=? WHERE id = ?`,
+		p.Principal, p.CreatedAt, p.LoanID)
+
+	return err
+}
+
+// setPayments moves the loan payments recorded for the transactions matching where from status from to status to,
+// adding sign times their principal back to the outstanding principal of their loan. Payments are 1 while they
+// count, 0 while their transaction is in the trash and 2 once it was voided, reversed or changed.
+func (m *mySqlTrxRepository) setPayments(ctx context.Context, tx *sql.Tx, userId int, where string, from int, to int, sign int) error {
+	query := `UPDATE loan_payments p JOIN loans l ON p.loan_id=l.id JOIN transactions t ON p.transaction_id=t.id
+		SET l.outstanding_principal = l.outstanding_principal + ? * p.principal, p.status=?
+		WHERE p.status=? AND t.user_id = ? AND t.` + where
+
+	_, err := tx.ExecContext(ctx, query, sign, to, from, userId)
+
+	return err
 }
 
-func (m *mySqlTrxRepository) DailySummary(ctx context.Context) ([]*models.SummaryDaily, error) {
-	query := `SELECT Avg(NULLIF(amount_in ,0)) as avgIn, Avg(NULLIF(amount_out ,0)) as avgOut, day(created_at) as day, month(created_at) as month, year(created_at) as year FROM transactions GROUP BY day(created_at), month(created_at), year(created_at);`
-	rows, err := m.Conn.QueryContext(ctx, query)
+// snapshot reads the transactions of the user matching where, active or not, with their splits, as seen from tx.
+func (m *mySqlTrxRepository) snapshot(ctx context.Context, tx *sql.Tx, userId int, where string) ([]*models.Transaction, error) {
+	list, err := m.fetch(ctx, tx, selectTrx+` WHERE t.user_id=? AND t.`+where+` ORDER BY t.id`, userId)
 
 	if err != nil {
-		logrus.Error(err)
 		return nil, err
 	}
 
-	defer func() {
-		err := rows.Close()
-		if err != nil {
-			logrus.Error(err)
-		}
-	}()
+	if err = m.fetchSplits(ctx, tx, list); err != nil {
+		return nil, err
+	}
 
-	result := make([]*models.SummaryDaily, 0)
+	return list, nil
+}
 
-	for rows.Next() {
-		t := new(models.SummaryDaily)
+// insert stores t with its splits, records the loan payment it makes if any, applies it to the balance of its
+// account and logs it under action.
+func (m *mySqlTrxRepository) insert(ctx context.Context, tx *sql.Tx, t *models.Transaction, action string) error {
+	query := `INSERT transactions SET user_id=?, name=?, account_id=?, category_id=?, type=?, description=?, amount_in=?, amount_out=?, trx_date=?, fitid=?, reversal_of=?, voided=?, created_at=?, updated_at=?`
 
-		err = rows.Scan(
-			&t.AverageIn,
-			&t.AverageOut,
-			&t.Day,
-			&t.Month,
-			&t.Year,
-		)
+	fitId := sql.NullString{String: t.FitID, Valid: t.FitID != ""}
+	reversalOf := sql.NullInt64{Int64: int64(t.ReversalOf), Valid: t.ReversalOf != 0}
+	voided := 0
 
-		if err != nil {
-			logrus.Error(err)
-			return nil, err
-		}
+	if t.Voided {
+		voided = 1
+	}
 
-		result = append(result, t)
+	payment, err := m.payLoan(ctx, tx, t)
+
+	if err != nil {
+		return err
 	}
 
-	return result, nil
-}
+	res, err := tx.ExecContext(ctx, query, t.UserID, t.Name, t.Account.ID, nullableCategory(t.Category), t.Type, t.Description, t.AmountIn, t.AmountOut, t.Date.Format("2006-01-02"), fitId, reversalOf, voided, t.CreatedAt, t.UpdatedAt)
 
-func (m *mySqlTrxRepository) MonthlySummary(ctx context.Context) ([]*models.SummaryMonthly, error) {
-	query := `SELECT Avg(NULLIF(amount_in ,0)) as avgIn, Avg(NULLIF(amount_out ,0)) as avgOut, month(created_at) as month, year(created_at) as year FROM transactions GROUP BY month(created_at), year(created_at);`
-	rows, err := m.Conn.QueryContext(ctx, query)
+	if me, ok := err.(*mysql.MySQLError); ok && me.Number == errDuplicateEntry {
+		return helpers.ErrConflict
+	}
 
 	if err != nil {
-		logrus.Error(err)
-		return nil, err
+		return err
 	}
 
-	defer func() {
-		err := rows.Close()
-		if err != nil {
-			logrus.Error(err)
-		}
-	}()
+	lastID, err := res.LastInsertId()
 
-	result := make([]*models.SummaryMonthly, 0)
+	if err != nil {
+		return err
+	}
 
-	for rows.Next() {
-		t := new(models.SummaryMonthly)
+	t.ID = int(lastID)
 
-		err = rows.Scan(
-			&t.AverageIn,
-			&t.AverageOut,
-			&t.Month,
-			&t.Year,
+	err = m.storeSplits(ctx, tx, t)
+
+	if err != nil {
+		return err
+	}
+
+	if err = m.storePayment(ctx, tx, t, payment); err != nil {
+		return err
+	}
+
+	if err = m.adjustBalance(ctx, tx, t.UserID, t.Account.ID, t.AmountIn, t.AmountOut); err != nil {
+		return err
+	}
+
+	return adr.Write(ctx, tx, t.UserID, t.UserID, audit.EntityTransaction, t.ID, action, nil, t)
+}
+
+func (m *mySqlTrxRepository) Store(ctx context.Context, t *models.Transaction) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := pdr.CheckOpen(ctx, tx, t.UserID, t.Date); err != nil {
+			return err
+		}
+
+		return m.insert(ctx, tx, t, "create")
+	})
+}
+
+// StoreBatch stores list as a whole, refusing it when any of its transactions falls in a closed period.
+func (m *mySqlTrxRepository) StoreBatch(ctx context.Context, list []*models.Transaction) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, len(list))
+
+	for i, t := range list {
+		dates[i] = t.Date
+	}
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := pdr.CheckOpen(ctx, tx, list[0].UserID, dates...); err != nil {
+			return err
+		}
+
+		for _, t := range list {
+			if err := m.insert(ctx, tx, t, "import"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *mySqlTrxRepository) Update(ctx context.Context, t *models.Transaction) error {
+	query := `UPDATE transactions SET name=?, account_id=?, category_id=?, type=?, description=?, amount_in=?, amount_out=?, trx_date=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		old, err := m.lockAmounts(ctx, tx, t.UserID, t.ID)
+
+		if err != nil {
+			return err
+		}
+
+		// Moving a transaction out of a closed period changes the period just as much as moving one into it.
+		if err = pdr.CheckOpen(ctx, tx, t.UserID, old.Date, t.Date); err != nil {
+			return err
+		}
+
+		where := fmt.Sprintf("id = %d", t.ID)
+
+		before, err := m.snapshot(ctx, tx, t.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		// The payment the transaction made is cancelled first, so that a new split sees its principal owed again.
+		err = m.setPayments(ctx, tx, t.UserID, where, 1, 2, 1)
+
+		if err != nil {
+			return err
+		}
+
+		payment, err := m.payLoan(ctx, tx, t)
+
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, t.Name, t.Account.ID, nullableCategory(t.Category), t.Type, t.Description, t.AmountIn, t.AmountOut, t.Date.Format("2006-01-02"), t.UpdatedAt, t.UserID, t.ID)
+		if err != nil {
+			return err
+		}
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affect != 1 {
+			err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+			return err
+		}
+
+		err = m.storeSplits(ctx, tx, t)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.storePayment(ctx, tx, t, payment); err != nil {
+			return err
+		}
+
+		err = m.adjustBalance(ctx, tx, t.UserID, old.Account.ID, old.AmountOut, old.AmountIn)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.adjustBalance(ctx, tx, t.UserID, t.Account.ID, t.AmountIn, t.AmountOut); err != nil {
+			return err
+		}
+
+		after, err := m.snapshot(ctx, tx, t.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, t.UserID, t.UserID, audit.EntityTransaction, t.ID, "update", before[0], after[0])
+	})
+}
+
+func (m *mySqlTrxRepository) Void(ctx context.Context, original *models.Transaction, reversal *models.Transaction) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := m.lockReversible(ctx, tx, original.UserID, original.ID); err != nil {
+			return err
+		}
+
+		if err := pdr.CheckOpen(ctx, tx, original.UserID, reversal.Date); err != nil {
+			return err
+		}
+
+		where := fmt.Sprintf("id = %d", original.ID)
+
+		before, err := m.snapshot(ctx, tx, original.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		err = m.insert(ctx, tx, reversal, "void")
+
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE transactions SET voided=1, voided_by=?, void_reason=?, updated_at=? WHERE user_id = ? AND id = ?`
+
+		_, err = tx.ExecContext(ctx, query, reversal.ID, original.VoidReason, original.UpdatedAt, original.UserID, original.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.setPayments(ctx, tx, original.UserID, where, 1, 2, 1); err != nil {
+			return err
+		}
+
+		after, err := m.snapshot(ctx, tx, original.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, original.UserID, original.UserID, audit.EntityTransaction, original.ID, "void", before[0], after[0])
+	})
+}
+
+// lockReversible locks an active transaction of the user that may still be voided or reversed: it fails with
+// ErrVoided, ErrReconciled or ErrConflict when it is voided, reconciled or already reversed.
+func (m *mySqlTrxRepository) lockReversible(ctx context.Context, tx *sql.Tx, userId int, id int) error {
+	voided, cleared := int(0), int(0)
+	reversedBy := sql.NullInt64{}
+	query := `SELECT voided, cleared, reversed_by FROM transactions WHERE status=1 AND user_id = ? AND id = ? FOR UPDATE`
+
+	err := tx.QueryRowContext(ctx, query, userId, id).Scan(&voided, &cleared, &reversedBy)
+
+	if err == sql.ErrNoRows {
+		return helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case voided == 1:
+		return helpers.ErrVoided
+	case cleared == 2:
+		return helpers.ErrReconciled
+	case reversedBy.Valid:
+		return helpers.ErrConflict
+	}
+
+	return nil
+}
+
+func (m *mySqlTrxRepository) Reverse(ctx context.Context, original *models.Transaction, reversal *models.Transaction) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := m.lockReversible(ctx, tx, original.UserID, original.ID); err != nil {
+			return err
+		}
+
+		if err := pdr.CheckOpen(ctx, tx, original.UserID, reversal.Date); err != nil {
+			return err
+		}
+
+		where := fmt.Sprintf("id = %d", original.ID)
+
+		before, err := m.snapshot(ctx, tx, original.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.insert(ctx, tx, reversal, "reverse"); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET reversed_by=? WHERE user_id = ? AND id = ?`, reversal.ID, original.UserID, original.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.setPayments(ctx, tx, original.UserID, where, 1, 2, 1); err != nil {
+			return err
+		}
+
+		after, err := m.snapshot(ctx, tx, original.UserID, where)
+
+		if err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, original.UserID, original.UserID, audit.EntityTransaction, original.ID, "reverse", before[0], after[0])
+	})
+}
+
+func (m *mySqlTrxRepository) Delete(ctx context.Context, userId int, id int) error {
+	query := `UPDATE transactions SET status=0, deleted_at=? WHERE user_id = ? AND id = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		old, err := m.lockAmounts(ctx, tx, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = pdr.CheckOpen(ctx, tx, userId, old.Date); err != nil {
+			return err
+		}
+
+		where := fmt.Sprintf("id = %d", id)
+
+		before, err := m.snapshot(ctx, tx, userId, where)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, time.Now(), userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.setPayments(ctx, tx, userId, where, 1, 0, 1); err != nil {
+			return err
+		}
+
+		if err = m.adjustBalance(ctx, tx, userId, old.Account.ID, old.AmountOut, old.AmountIn); err != nil {
+			return err
+		}
+
+		return adr.Write(ctx, tx, userId, userId, audit.EntityTransaction, id, "delete", before[0], nil)
+	})
+}
+
+func (m *mySqlTrxRepository) FetchDeleted(ctx context.Context, userId int) ([]*models.Transaction, error) {
+	query := selectTrx + ` WHERE t.status=0 AND t.deleted_at IS NOT NULL AND t.deleted_with_account IS NULL AND t.trade_id IS NULL AND t.user_id=? ORDER BY t.deleted_at DESC, t.id`
+
+	list, err := m.fetch(ctx, m.Conn, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.fetchSplits(ctx, m.Conn, list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Restore brings a transaction back from the trash, or both legs and the transfer when it is part of one, and
+// applies it to the account balances again. Transactions of a deleted account must be restored with the account,
+// and those of a deleted trade cannot be restored.
+func (m *mySqlTrxRepository) Restore(ctx context.Context, userId int, id int) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		transferId := sql.NullInt64{}
+		query := `SELECT transfer_id FROM transactions WHERE status=0 AND deleted_at IS NOT NULL AND deleted_with_account IS NULL AND trade_id IS NULL AND user_id = ? AND id = ? FOR UPDATE`
+
+		err := tx.QueryRowContext(ctx, query, userId, id).Scan(&transferId)
+
+		if err == sql.ErrNoRows {
+			return helpers.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		where := fmt.Sprintf("id = %d", id)
+
+		if transferId.Valid {
+			where = fmt.Sprintf("transfer_id = %d", transferId.Int64)
+
+			_, err = tx.ExecContext(ctx, `UPDATE transfers SET status=1 WHERE user_id = ? AND id = ?`, userId, transferId.Int64)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		first := sql.NullTime{}
+		query = `SELECT MIN(trx_date) FROM transactions WHERE status=0 AND user_id = ? AND ` + where
+
+		if err = tx.QueryRowContext(ctx, query, userId).Scan(&first); err != nil {
+			return err
+		}
+
+		if err = pdr.CheckOpen(ctx, tx, userId, first.Time); err != nil {
+			return err
+		}
+
+		inactive := int(0)
+		query = `SELECT COUNT(t.id) FROM transactions t JOIN accounts a ON t.account_id=a.id WHERE t.status=0 AND t.user_id = ? AND t.` + where + ` AND a.status <> 1`
+
+		if err = tx.QueryRowContext(ctx, query, userId).Scan(&inactive); err != nil {
+			return err
+		}
+
+		if inactive > 0 {
+			return helpers.ErrConflict
+		}
+
+		query = `UPDATE accounts a JOIN (SELECT account_id, SUM(amount_in - amount_out) AS net FROM transactions
+			WHERE status=0 AND user_id = ? AND ` + where + ` GROUP BY account_id) t ON a.id=t.account_id SET a.balance = a.balance + t.net`
+
+		_, err = tx.ExecContext(ctx, query, userId)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET status=1, deleted_at=NULL WHERE status=0 AND user_id = ? AND `+where, userId)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.setPayments(ctx, tx, userId, where, 0, 1, -1); err != nil {
+			return err
+		}
+
+		restored, err := m.snapshot(ctx, tx, userId, where)
+
+		if err != nil {
+			return err
+		}
+
+		for _, t := range restored {
+			if err = adr.Write(ctx, tx, userId, userId, audit.EntityTransaction, t.ID, "restore", nil, t); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// purgeLinks keeps in the trash the rows that something outside the purge still points at: reconciled rows, the cash
+// legs of trades that are still active, and either side of a void or reversal whose partner stays behind.
+const purgeLinks = `t.cleared<>2
+	AND NOT EXISTS (SELECT 1 FROM trades tr WHERE tr.id=t.trade_id AND tr.status=1)
+	AND NOT EXISTS (SELECT 1 FROM transactions o WHERE (o.reversal_of=t.id OR o.reversed_by=t.id OR o.voided_by=t.id OR o.id=t.reversal_of OR o.id=t.reversed_by OR o.id=t.voided_by) AND %s)`
+
+func (m *mySqlTrxRepository) FetchPurgeable(ctx context.Context, before time.Time) ([]*models.Transaction, error) {
+	query := `SELECT t.id, t.user_id FROM transactions t WHERE t.status=0 AND t.deleted_at < ? AND ` +
+		fmt.Sprintf(purgeLinks, `NOT (o.status=0 AND o.deleted_at < ?)`) + ` ORDER BY t.id`
+
+	rows, err := m.Conn.QueryContext(ctx, query, before, before)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Transaction, 0)
+
+	for rows.Next() {
+		t := new(models.Transaction)
+
+		err = rows.Scan(
+			&t.ID,
+			&t.UserID,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlTrxRepository) Purge(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var purged []int
+
+	err := m.withTx(ctx, func(tx *sql.Tx) error {
+		// The links are checked again under lock: a partner may have been restored, or left out of the list.
+		list := joinIds(ids)
+		query := fmt.Sprintf(`SELECT t.id FROM transactions t WHERE t.status=0 AND t.id IN (%s) AND `, list) +
+			fmt.Sprintf(purgeLinks, fmt.Sprintf(`o.id NOT IN (%s)`, list)) + ` FOR UPDATE`
+
+		rows, err := tx.QueryContext(ctx, query)
+
+		if err != nil {
+			return err
+		}
+
+		safe := make([]int, 0, len(ids))
+
+		for rows.Next() {
+			var id int
+
+			if err = rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+
+			safe = append(safe, id)
+		}
+
+		if err = rows.Close(); err != nil {
+			return err
+		}
+
+		if len(safe) == 0 {
+			return nil
+		}
+
+		list = joinIds(safe)
+
+		for _, q := range []string{
+			`DELETE FROM transaction_splits WHERE transaction_id IN (%s)`,
+			`DELETE FROM loan_payments WHERE transaction_id IN (%s)`,
+			`UPDATE trades SET transaction_id=NULL WHERE transaction_id IN (%s)`,
+			`UPDATE recurring_occurrences SET transaction_id=0 WHERE transaction_id IN (%s)`,
+			`DELETE FROM transactions WHERE status=0 AND id IN (%s)`,
+		} {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf(q, list)); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM transfers WHERE status=0 AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.transfer_id=transfers.id)`)
+
+		if err != nil {
+			return err
+		}
+
+		purged = safe
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+// lockAmounts reads the stored account, amounts and date of an active transaction, locking the row until the
+// surrounding database transaction ends. It fails with ErrReconciled when a finalized reconciliation holds the row.
+func (m *mySqlTrxRepository) lockAmounts(ctx context.Context, tx *sql.Tx, userId int, id int) (*models.Transaction, error) {
+	query := `SELECT account_id, amount_in, amount_out, trx_date, cleared FROM transactions WHERE status=1 AND user_id = ? AND id = ? FOR UPDATE`
+
+	t := new(models.Transaction)
+	cleared := int(0)
+
+	err := tx.QueryRowContext(ctx, query, userId, id).Scan(&t.Account.ID, &t.AmountIn, &t.AmountOut, &t.Date, &cleared)
+
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cleared == 2 {
+		return nil, helpers.ErrReconciled
+	}
+
+	return t, nil
+}
+
+// summaryFilter keeps the transactions of t that count in the summaries: active ones that are neither a transfer leg
+// nor either side of a void or reversal, since each such pair cancels out.
+const summaryFilter = `t.status=1 AND t.transfer_id IS NULL AND t.voided=0 AND t.reversal_of IS NULL AND t.reversed_by IS NULL`
+
+func (m *mySqlTrxRepository) DailySummary(ctx context.Context, userId int) ([]*models.SummaryDaily, error) {
+	query := `SELECT Avg(NULLIF(amount_in ,0)) as avgIn, Avg(NULLIF(amount_out ,0)) as avgOut, day(trx_date) as day, month(trx_date) as month, year(trx_date) as year FROM transactions t WHERE t.user_id = ? AND ` + summaryFilter + ` GROUP BY day(trx_date), month(trx_date), year(trx_date);`
+	rows, err := m.Conn.QueryContext(ctx, query, userId)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.SummaryDaily, 0)
+
+	for rows.Next() {
+		t := new(models.SummaryDaily)
+
+		err = rows.Scan(
+			&t.AverageIn,
+			&t.AverageOut,
+			&t.Day,
+			&t.Month,
+			&t.Year,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlTrxRepository) MonthlySummary(ctx context.Context, userId int) ([]*models.SummaryMonthly, error) {
+	query := `SELECT Avg(NULLIF(amount_in ,0)) as avgIn, Avg(NULLIF(amount_out ,0)) as avgOut, month(trx_date) as month, year(trx_date) as year FROM transactions t WHERE t.user_id = ? AND ` + summaryFilter + ` GROUP BY month(trx_date), year(trx_date);`
+	rows, err := m.Conn.QueryContext(ctx, query, userId)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.SummaryMonthly, 0)
+
+	for rows.Next() {
+		t := new(models.SummaryMonthly)
+
+		err = rows.Scan(
+			&t.AverageIn,
+			&t.AverageOut,
+			&t.Month,
+			&t.Year,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mySqlTrxRepository) FetchSummaryItems(ctx context.Context, userId int) ([]*models.Transaction, error) {
+	query := `SELECT t.id, t.account_id, t.amount_in, t.amount_out, t.trx_date, a.currency FROM transactions t LEFT JOIN accounts a ON t.account_id=a.id WHERE t.user_id = ? AND ` + summaryFilter
+	rows, err := m.Conn.QueryContext(ctx, query, userId)
+
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	result := make([]*models.Transaction, 0)
+
+	for rows.Next() {
+		t := new(models.Transaction)
+
+		err = rows.Scan(
+			&t.ID,
+			&t.Account.ID,
+			&t.AmountIn,
+			&t.AmountOut,
+			&t.Date,
+			&t.Account.Currency,
 		)
 
 		if err != nil {
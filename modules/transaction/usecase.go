@@ -2,16 +2,30 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/arham09/fin-api/models"
 )
 
 type Usecase interface {
-	FetchAll(c context.Context, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Transaction, int, error)
-	FetchById(c context.Context, id int) (*models.Transaction, error)
+	FetchAll(c context.Context, userId int, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Transaction, int, error)
+	// Export calls fn for every transaction matching the FetchAll filters, streaming them from the database. It is
+	// bounded by c rather than the usecase timeout, since its duration grows with the number of transactions.
+	Export(c context.Context, userId int, filter map[string]interface{}, keyword string, fn func(t *models.Transaction) error) error
+	FetchById(c context.Context, userId int, id int) (*models.Transaction, error)
 	Create(c context.Context, trx *models.Transaction) error
 	Update(c context.Context, trx *models.Transaction) (*models.Transaction, error)
-	Delete(c context.Context, id int) error
-	DailySummary(c context.Context) ([]*models.SummaryDaily, error)
-	MonnthlySummary(c context.Context) ([]*models.SummaryMonthly, error)
+	Delete(c context.Context, userId int, id int) error
+	// Restore brings a transaction back from the trash; restoring either leg of a transfer restores both.
+	Restore(c context.Context, userId int, id int) (*models.Transaction, error)
+	// Reverse posts an entry cancelling the transaction, dated date or today when date is zero, which must fall in
+	// an open period. It is the only way to correct a transaction locked by a period close. The pair is kept out of
+	// the summaries.
+	Reverse(c context.Context, userId int, id int, date time.Time) (*models.Transaction, error)
+	// Void cancels a transaction with a reversal dated today and links the two. The pair is kept out of the
+	// summaries and can no longer be changed, deleted or voided again.
+	Void(c context.Context, userId int, id int, reason string) (*models.VoidResult, error)
+	// DailySummary and MonnthlySummary convert every amount to currency when it is set.
+	DailySummary(c context.Context, userId int, currency string) ([]*models.SummaryDaily, error)
+	MonnthlySummary(c context.Context, userId int, currency string) ([]*models.SummaryMonthly, error)
 }
@@ -2,34 +2,50 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/arham09/fin-api/helpers"
 	"github.com/arham09/fin-api/models"
 	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/category"
+	"github.com/arham09/fin-api/modules/exchangerate"
 	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/arham09/fin-api/modules/transfer"
 )
 
 type transactionUsecase struct {
 	trxRepo        transaction.Repository
 	accountRepo    account.Repository
+	transferRepo   transfer.Repository
+	rateRepo       exchangerate.Repository
+	categoryRepo   category.Repository
 	contextTimeout time.Duration
 }
 
-func NewTrxRepo(t transaction.Repository, a account.Repository, timeout time.Duration) transaction.Usecase {
+func NewTrxRepo(t transaction.Repository, a account.Repository, tf transfer.Repository, r exchangerate.Repository, c category.Repository, timeout time.Duration) transaction.Usecase {
 	return &transactionUsecase{
 		trxRepo:        t,
 		accountRepo:    a,
+		transferRepo:   tf,
+		rateRepo:       r,
+		categoryRepo:   c,
 		contextTimeout: timeout,
 	}
 }
 
-func (t *transactionUsecase) FetchAll(c context.Context, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Transaction, int, error) {
+func (t *transactionUsecase) FetchAll(c context.Context, userId int, filter map[string]interface{}, keyword string, limit int, offset int) ([]*models.Transaction, int, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 
 	defer cancel()
 
-	res, total, err := t.trxRepo.FetchAll(ctx, filter, keyword, limit, offset)
+	if err := t.normalizeFilter(ctx, userId, filter); err != nil {
+		return nil, 0, err
+	}
+
+	res, total, err := t.trxRepo.FetchAll(ctx, userId, filter, keyword, limit, offset)
 
 	if err != nil {
 		return nil, 0, err
@@ -38,12 +54,144 @@ func (t *transactionUsecase) FetchAll(c context.Context, filter map[string]inter
 	return res, total, nil
 }
 
-func (t *transactionUsecase) FetchById(c context.Context, id int) (*models.Transaction, error) {
+// normalizeFilter checks the filters that need more than a column comparison and converts them to the values
+// expected by the repository.
+func (t *transactionUsecase) normalizeFilter(ctx context.Context, userId int, filter map[string]interface{}) error {
+	// Filtering by a category also matches the transactions of every category below it.
+	if param, ok := filter["categoryId"]; ok {
+		categoryId, err := strconv.Atoi(param.(string))
+
+		if err != nil {
+			return helpers.ErrBadParamInput
+		}
+
+		_, err = t.categoryRepo.FetchById(ctx, userId, categoryId)
+
+		if err != nil {
+			return err
+		}
+
+		list, err := t.categoryRepo.FetchAll(ctx, userId)
+
+		if err != nil {
+			return err
+		}
+
+		filter["categoryId"] = category.Descendants(list, categoryId)
+	}
+
+	// Date range bounds are inclusive and compared with the value date of the transactions.
+	for _, bound := range []string{"from", "to"} {
+		if param, ok := filter[bound]; ok {
+			date, err := time.Parse("2006-01-02", param.(string))
+
+			if err != nil {
+				return helpers.ErrBadParamInput
+			}
+
+			filter[bound] = date
+		}
+	}
+
+	return nil
+}
+
+func (t *transactionUsecase) Export(c context.Context, userId int, filter map[string]interface{}, keyword string, fn func(t *models.Transaction) error) error {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	err := t.normalizeFilter(ctx, userId, filter)
+
+	cancel()
+
+	if err != nil {
+		return err
+	}
+
+	return t.trxRepo.Stream(c, userId, filter, keyword, fn)
+}
+
+// validateAmount requires a positive amount in the direction of the transaction and none in the other.
+func validateAmount(trx *models.Transaction) error {
+	amount, other := trx.AmountIn, trx.AmountOut
+
+	if trx.Type == "out" {
+		amount, other = other, amount
+	}
+
+	if amount <= 0 || other != 0 {
+		return helpers.ErrBadParamInput
+	}
+
+	return nil
+}
+
+// validateCategory checks that the category of trx, if any, belongs to the user and matches the transaction type.
+func (t *transactionUsecase) validateCategory(ctx context.Context, trx *models.Transaction) error {
+	if trx.Category == nil {
+		return nil
+	}
+
+	cat, err := t.categoryRepo.FetchById(ctx, trx.UserID, trx.Category.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if cat.Type != trx.Type {
+		return helpers.ErrBadParamInput
+	}
+
+	trx.Category = cat
+
+	return nil
+}
+
+// validateSplits checks that the splits of trx, if any, add up to its amount and use categories of the user
+// that match the transaction type.
+func (t *transactionUsecase) validateSplits(ctx context.Context, trx *models.Transaction) error {
+	if len(trx.Splits) == 0 {
+		return nil
+	}
+
+	var total models.Money
+
+	for _, s := range trx.Splits {
+		if s.Amount <= 0 {
+			return helpers.ErrBadParamInput
+		}
+
+		total += s.Amount
+
+		if s.Category == nil {
+			continue
+		}
+
+		cat, err := t.categoryRepo.FetchById(ctx, trx.UserID, s.Category.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if cat.Type != trx.Type {
+			return helpers.ErrBadParamInput
+		}
+
+		s.Category = cat
+	}
+
+	if total != trx.AmountIn+trx.AmountOut {
+		return helpers.ErrSplitMismatch
+	}
+
+	return nil
+}
+
+func (t *transactionUsecase) FetchById(c context.Context, userId int, id int) (*models.Transaction, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 
 	defer cancel()
 
-	res, err := t.trxRepo.FetchById(ctx, id)
+	res, err := t.trxRepo.FetchById(ctx, userId, id)
 
 	if err != nil {
 		return nil, err
@@ -52,11 +200,11 @@ func (t *transactionUsecase) FetchById(c context.Context, id int) (*models.Trans
 	return res, nil
 }
 
-func (t *transactionUsecase) Delete(c context.Context, id int) error {
+func (t *transactionUsecase) Delete(c context.Context, userId int, id int) error {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 	defer cancel()
 
-	existEmail, err := t.trxRepo.FetchById(ctx, id)
+	existEmail, err := t.trxRepo.FetchById(ctx, userId, id)
 
 	if err != nil {
 		return err
@@ -66,7 +214,64 @@ func (t *transactionUsecase) Delete(c context.Context, id int) error {
 		return helpers.ErrNotFound
 	}
 
-	return t.trxRepo.Delete(ctx, id)
+	if existEmail.Cleared == "reconciled" {
+		return helpers.ErrReconciled
+	}
+
+	if existEmail.Voided {
+		return helpers.ErrVoided
+	}
+
+	// Either side of a reversal only cancels the other as posted, so the pair stays as it is.
+	if existEmail.ReversedBy != 0 || existEmail.ReversalOf != 0 {
+		return helpers.ErrConflict
+	}
+
+	if existEmail.TradeID != 0 {
+		return helpers.ErrLinkedTrade
+	}
+
+	// Deleting either side of a transfer removes the whole transfer so the pair never drifts apart.
+	if existEmail.TransferID != 0 {
+		tr, err := t.transferRepo.FetchById(ctx, userId, existEmail.TransferID)
+
+		if err != nil {
+			return err
+		}
+
+		other := tr.InTrxID
+
+		if other == id {
+			other = tr.OutTrxID
+		}
+
+		otherTrx, err := t.trxRepo.FetchById(ctx, userId, other)
+
+		if err != nil {
+			return err
+		}
+
+		if otherTrx.Cleared == "reconciled" {
+			return helpers.ErrReconciled
+		}
+
+		return t.transferRepo.Delete(ctx, userId, tr.ID)
+	}
+
+	return t.trxRepo.Delete(ctx, userId, id)
+}
+
+func (t *transactionUsecase) Restore(c context.Context, userId int, id int) (*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	err := t.trxRepo.Restore(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.trxRepo.FetchById(ctx, userId, id)
 }
 
 func (t *transactionUsecase) Create(c context.Context, trx *models.Transaction) error {
@@ -74,15 +279,40 @@ func (t *transactionUsecase) Create(c context.Context, trx *models.Transaction)
 
 	defer cancel()
 
-	trx.CreatedAt = time.Now()
-	trx.UpdatedAt = time.Now()
+	if err := validateAmount(trx); err != nil {
+		return err
+	}
 
-	err := t.trxRepo.Store(ctx, trx)
+	accountId, err := t.accountRepo.FetchById(ctx, trx.UserID, trx.Account.ID)
 
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if accountId == nil {
+		return helpers.ErrNotFound
+	}
+
+	err = t.validateCategory(ctx, trx)
+
+	if err != nil {
+		return err
+	}
+
+	err = t.validateSplits(ctx, trx)
+
+	if err != nil {
+		return err
+	}
+
+	if trx.Date.IsZero() {
+		trx.Date = time.Now()
+	}
+
+	trx.CreatedAt = time.Now()
+	trx.UpdatedAt = time.Now()
+
+	return t.trxRepo.Store(ctx, trx)
 }
 
 func (t *transactionUsecase) Update(c context.Context, trx *models.Transaction) (*models.Transaction, error) {
@@ -90,7 +320,11 @@ func (t *transactionUsecase) Update(c context.Context, trx *models.Transaction)
 
 	defer cancel()
 
-	existID, err := t.trxRepo.FetchById(ctx, trx.ID)
+	if err := validateAmount(trx); err != nil {
+		return nil, err
+	}
+
+	existID, err := t.trxRepo.FetchById(ctx, trx.UserID, trx.ID)
 
 	if err != nil {
 		return nil, err
@@ -100,7 +334,27 @@ func (t *transactionUsecase) Update(c context.Context, trx *models.Transaction)
 		return nil, helpers.ErrNotFound
 	}
 
-	accountId, err := t.accountRepo.FetchById(ctx, trx.Account.ID)
+	if existID.TransferID != 0 {
+		return nil, helpers.ErrLinkedTransfer
+	}
+
+	if existID.TradeID != 0 {
+		return nil, helpers.ErrLinkedTrade
+	}
+
+	if existID.Cleared == "reconciled" {
+		return nil, helpers.ErrReconciled
+	}
+
+	if existID.Voided {
+		return nil, helpers.ErrVoided
+	}
+
+	if existID.ReversedBy != 0 || existID.ReversalOf != 0 {
+		return nil, helpers.ErrConflict
+	}
+
+	accountId, err := t.accountRepo.FetchById(ctx, trx.UserID, trx.Account.ID)
 
 	if err != nil {
 		return nil, err
@@ -110,6 +364,22 @@ func (t *transactionUsecase) Update(c context.Context, trx *models.Transaction)
 		return nil, helpers.ErrNotFound
 	}
 
+	err = t.validateCategory(ctx, trx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.validateSplits(ctx, trx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if trx.Date.IsZero() {
+		trx.Date = existID.Date
+	}
+
 	trx.UpdatedAt = time.Now()
 
 	err = t.trxRepo.Update(c, trx)
@@ -118,39 +388,307 @@ func (t *transactionUsecase) Update(c context.Context, trx *models.Transaction)
 		return nil, err
 	}
 
-	res, err := t.trxRepo.FetchById(c, trx.ID)
+	return t.trxRepo.FetchById(c, trx.UserID, trx.ID)
+}
+
+// newReversal builds the entry cancelling existing, dated date: the amounts swap sides and the splits carry over,
+// since their amounts follow the direction of their transaction.
+func newReversal(existing *models.Transaction, date time.Time) *models.Transaction {
+	reversal := &models.Transaction{
+		UserID:      existing.UserID,
+		Name:        existing.Name,
+		Type:        "in",
+		Description: fmt.Sprintf("Reversal of transaction %d", existing.ID),
+		AmountIn:    existing.AmountOut,
+		AmountOut:   existing.AmountIn,
+		ReversalOf:  existing.ID,
+		Account:     models.Account{ID: existing.Account.ID},
+		Category:    existing.Category,
+		Date:        date,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if existing.Type == "in" {
+		reversal.Type = "out"
+	}
+
+	for _, s := range existing.Splits {
+		reversal.Splits = append(reversal.Splits, &models.TransactionSplit{
+			Amount:   s.Amount,
+			Category: s.Category,
+			Memo:     s.Memo,
+		})
+	}
+
+	return reversal
+}
+
+// fetchReversible returns the transaction for Reverse and Void, which only apply to active transactions of an
+// active account that are neither transfer legs, the cash of a trade nor part of a void.
+func (t *transactionUsecase) fetchReversible(ctx context.Context, userId int, id int) (*models.Transaction, error) {
+	existing, err := t.trxRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.TransferID != 0 {
+		return nil, helpers.ErrLinkedTransfer
+	}
+
+	if existing.TradeID != 0 {
+		return nil, helpers.ErrLinkedTrade
+	}
+
+	if existing.Voided {
+		return nil, helpers.ErrVoided
+	}
+
+	if existing.Cleared == "reconciled" {
+		return nil, helpers.ErrReconciled
+	}
+
+	if existing.ReversedBy != 0 {
+		return nil, helpers.ErrConflict
+	}
+
+	if _, err = t.accountRepo.FetchById(ctx, userId, existing.Account.ID); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (t *transactionUsecase) Reverse(c context.Context, userId int, id int, date time.Time) (*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	existing, err := t.fetchReversible(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	reversal := newReversal(existing, date)
+
+	err = t.trxRepo.Reverse(ctx, existing, reversal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.trxRepo.FetchById(ctx, userId, reversal.ID)
+}
+
+func (t *transactionUsecase) Void(c context.Context, userId int, id int, reason string) (*models.VoidResult, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	existing, err := t.fetchReversible(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	reversal := newReversal(existing, now)
+	reversal.Description = fmt.Sprintf("Void of transaction %d: %s", existing.ID, reason)
+	reversal.Voided = true
+
+	original := *existing
+	original.VoidReason = reason
+	original.UpdatedAt = now
+
+	err = t.trxRepo.Void(ctx, &original, reversal)
 
 	if err != nil {
 		return nil, err
 	}
 
+	res := new(models.VoidResult)
+
+	if res.Original, err = t.trxRepo.FetchById(ctx, userId, existing.ID); err != nil {
+		return nil, err
+	}
+
+	if res.Reversal, err = t.trxRepo.FetchById(ctx, userId, reversal.ID); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
-func (t *transactionUsecase) DailySummary(c context.Context) ([]*models.SummaryDaily, error) {
+// average accumulates the non-zero amounts of a summary bucket, matching AVG(NULLIF(amount, 0)) in SQL.
+type average struct {
+	sumIn, sumOut     models.Money
+	countIn, countOut int64
+}
+
+func (a *average) add(in models.Money, out models.Money) {
+	if in != 0 {
+		a.sumIn += in
+		a.countIn++
+	}
+
+	if out != 0 {
+		a.sumOut += out
+		a.countOut++
+	}
+}
+
+func (a *average) values() (models.Money, models.Money) {
+	var in, out models.Money
+
+	if a.countIn > 0 {
+		in = a.sumIn.DivRound(a.countIn)
+	}
+
+	if a.countOut > 0 {
+		out = a.sumOut.DivRound(a.countOut)
+	}
+
+	return in, out
+}
+
+// convertedItems returns the summary transactions with their amounts converted to currency using the rate
+// valid on each transaction's date.
+func (t *transactionUsecase) convertedItems(ctx context.Context, userId int, currency string) ([]*models.Transaction, error) {
+	items, err := t.trxRepo.FetchSummaryItems(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	converter := exchangerate.NewConverter(t.rateRepo, userId)
+
+	for _, item := range items {
+		item.AmountIn, err = converter.Convert(ctx, item.AmountIn, item.Account.Currency, currency, item.Date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item.AmountOut, err = converter.Convert(ctx, item.AmountOut, item.Account.Currency, currency, item.Date)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func (t *transactionUsecase) DailySummary(c context.Context, userId int, currency string) ([]*models.SummaryDaily, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 
 	defer cancel()
 
-	res, err := t.trxRepo.DailySummary(ctx)
+	if currency == "" {
+		return t.trxRepo.DailySummary(ctx, userId)
+	}
+
+	items, err := t.convertedItems(ctx, userId, currency)
 
 	if err != nil {
 		return nil, err
 	}
 
+	buckets := make(map[time.Time]*average)
+
+	for _, item := range items {
+		day := time.Date(item.Date.Year(), item.Date.Month(), item.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+		if buckets[day] == nil {
+			buckets[day] = new(average)
+		}
+
+		buckets[day].add(item.AmountIn, item.AmountOut)
+	}
+
+	res := make([]*models.SummaryDaily, 0, len(buckets))
+
+	for day, avg := range buckets {
+		in, out := avg.values()
+
+		res = append(res, &models.SummaryDaily{
+			Day:        day.Day(),
+			Month:      int(day.Month()),
+			Year:       day.Year(),
+			AverageIn:  in,
+			AverageOut: out,
+			Currency:   currency,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		return a.Day < b.Day
+	})
+
 	return res, nil
 }
 
-func (t *transactionUsecase) MonnthlySummary(c context.Context) ([]*models.SummaryMonthly, error) {
+func (t *transactionUsecase) MonnthlySummary(c context.Context, userId int, currency string) ([]*models.SummaryMonthly, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 
 	defer cancel()
 
-	res, err := t.trxRepo.MonthlySummary(ctx)
+	if currency == "" {
+		return t.trxRepo.MonthlySummary(ctx, userId)
+	}
+
+	items, err := t.convertedItems(ctx, userId, currency)
 
 	if err != nil {
 		return nil, err
 	}
 
+	buckets := make(map[time.Time]*average)
+
+	for _, item := range items {
+		month := time.Date(item.Date.Year(), item.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+		if buckets[month] == nil {
+			buckets[month] = new(average)
+		}
+
+		buckets[month].add(item.AmountIn, item.AmountOut)
+	}
+
+	res := make([]*models.SummaryMonthly, 0, len(buckets))
+
+	for month, avg := range buckets {
+		in, out := avg.values()
+
+		res = append(res, &models.SummaryMonthly{
+			Month:      int(month.Month()),
+			Year:       month.Year(),
+			AverageIn:  in,
+			AverageOut: out,
+			Currency:   currency,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Year != res[j].Year {
+			return res[i].Year < res[j].Year
+		}
+		return res[i].Month < res[j].Month
+	})
+
 	return res, nil
 }
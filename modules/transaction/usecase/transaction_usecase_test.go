@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/transaction"
+)
+
+// trxRepo serves one stored transaction and records the writes made to it. Methods the tests do not reach are
+// left to the embedded nil interface.
+type trxRepo struct {
+	transaction.Repository
+	stored  *models.Transaction
+	updated bool
+	deleted bool
+}
+
+func (r *trxRepo) FetchById(ctx context.Context, userId int, id int) (*models.Transaction, error) {
+	if r.stored.ID != id {
+		return nil, helpers.ErrNotFound
+	}
+
+	return r.stored, nil
+}
+
+func (r *trxRepo) Update(ctx context.Context, t *models.Transaction) error {
+	r.updated = true
+
+	return nil
+}
+
+func (r *trxRepo) Delete(ctx context.Context, userId int, id int) error {
+	r.deleted = true
+
+	return nil
+}
+
+type accountRepo struct {
+	account.Repository
+}
+
+func (r *accountRepo) FetchById(ctx context.Context, userId int, id int) (*models.Account, error) {
+	return &models.Account{ID: id}, nil
+}
+
+func newStored(edit func(t *models.Transaction)) *models.Transaction {
+	t := &models.Transaction{
+		ID:        7,
+		UserID:    1,
+		Type:      "out",
+		AmountOut: 100000,
+		Cleared:   "uncleared",
+		Account:   models.Account{ID: 3},
+		Date:      time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+	}
+
+	if edit != nil {
+		edit(t)
+	}
+
+	return t
+}
+
+var linkedCases = []struct {
+	name string
+	edit func(t *models.Transaction)
+	err  error
+}{
+	{name: "plain transaction"},
+	{name: "reversed original", edit: func(t *models.Transaction) { t.ReversedBy = 8 }, err: helpers.ErrConflict},
+	{name: "reversing entry", edit: func(t *models.Transaction) { t.ReversalOf = 6 }, err: helpers.ErrConflict},
+	{name: "voided original", edit: func(t *models.Transaction) { t.Voided, t.VoidedBy = true, 8 }, err: helpers.ErrVoided},
+	{name: "void reversal", edit: func(t *models.Transaction) { t.Voided, t.ReversalOf = true, 6 }, err: helpers.ErrVoided},
+	{name: "reconciled", edit: func(t *models.Transaction) { t.Cleared = "reconciled" }, err: helpers.ErrReconciled},
+	{name: "trade cash", edit: func(t *models.Transaction) { t.TradeID = 2 }, err: helpers.ErrLinkedTrade},
+}
+
+func TestDeleteRefusesLinkedRows(t *testing.T) {
+	for _, tt := range linkedCases {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &trxRepo{stored: newStored(tt.edit)}
+			uc := NewTrxRepo(repo, &accountRepo{}, nil, nil, nil, time.Second)
+
+			err := uc.Delete(context.Background(), 1, 7)
+
+			if err != tt.err {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.err)
+			}
+
+			if repo.deleted != (tt.err == nil) {
+				t.Errorf("Delete() reached the repository: %v", repo.deleted)
+			}
+		})
+	}
+}
+
+func TestUpdateRefusesLinkedRows(t *testing.T) {
+	for _, tt := range linkedCases {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &trxRepo{stored: newStored(tt.edit)}
+			uc := NewTrxRepo(repo, &accountRepo{}, nil, nil, nil, time.Second)
+
+			changed := newStored(nil)
+			changed.Name = "Renamed"
+			changed.AmountOut = 120000
+
+			_, err := uc.Update(context.Background(), changed)
+
+			if err != tt.err {
+				t.Fatalf("Update() error = %v, want %v", err, tt.err)
+			}
+
+			if repo.updated != (tt.err == nil) {
+				t.Errorf("Update() reached the repository: %v", repo.updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/transfer"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type TransferRequest struct {
+	FromAccountID int          `json:"fromAccountId" validate:"required"`
+	ToAccountID   int          `json:"toAccountId" validate:"required"`
+	Amount        models.Money `json:"amount" validate:"required"`
+	Description   string       `json:"description" validate:"required"`
+	Date          string       `json:"date"`
+}
+
+type TransferHandler struct {
+	TransferUsecase transfer.Usecase
+}
+
+func NewTransferHandler(e *echo.Echo, tu transfer.Usecase, middleware *middleware.Middleware) {
+	handler := &TransferHandler{
+		TransferUsecase: tu,
+	}
+
+	e.GET("/v1/transfer/:id", handler.FetchById, middleware.Authorize)
+	e.POST("/v1/transfer", handler.Create, middleware.Authorize)
+	e.PATCH("/v1/transfer/:id", handler.Update, middleware.Authorize)
+	e.DELETE("/v1/transfer/:id", handler.Delete, middleware.Authorize)
+}
+
+// ShowTransfer godoc
+// @Summary Show a Transfer
+// @Description get Transfer by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transfer id"
+// @Success 200 {object} models.Transfer
+// @Header 200 {string} Token "qwerty"
+// @Router /transfer/{id} [get]
+func (t *TransferHandler) FetchById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := t.TransferUsecase.FetchById(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateTransfer godoc
+// @Summary Create a Transfer
+// @Description Move money between two accounts of the same currency as a linked out/in pair of transactions. Transfers into a loan account with terms are refused
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param transfer body TransferRequest true "TransferRequest Body"
+// @Success 201 {object} models.Transfer
+// @Header 200 {string} Token "qwerty"
+// @Router /transfer [post]
+func (t *TransferHandler) Create(c echo.Context) error {
+	var req TransferRequest
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	tr, err := toTransfer(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	tr.UserID = c.Get("userId").(int)
+
+	err = t.TransferUsecase.Create(ctx, tr)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, tr)
+}
+
+// UpdateTransfer godoc
+// @Summary Update Transfer
+// @Description Update both transactions of a Transfer by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transfer id"
+// @Param transfer body TransferRequest true "TransferRequest Body"
+// @Success 200 {object} models.Transfer
+// @Header 200 {string} Token "qwerty"
+// @Router /transfer/{id} [patch]
+func (t *TransferHandler) Update(c echo.Context) error {
+	var req TransferRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = c.Bind(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, err := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	tr, err := toTransfer(&req)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	tr.ID = id
+	tr.UserID = c.Get("userId").(int)
+
+	res, err := t.TransferUsecase.Update(ctx, tr)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteTransfer godoc
+// @Summary Delete Transfer
+// @Description Delete both transactions of a Transfer by ID
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Param id path int true "Transfer id"
+// @Success 204
+// @Header 200 {string} Token "qwerty"
+// @Router /transfer/{id} [delete]
+func (t *TransferHandler) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusNotFound, helpers.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err = t.TransferUsecase.Delete(ctx, c.Get("userId").(int), id)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toTransfer(req *TransferRequest) (*models.Transfer, error) {
+	tr := new(models.Transfer)
+	tr.FromAccount.ID = req.FromAccountID
+	tr.ToAccount.ID = req.ToAccountID
+	tr.Amount = req.Amount
+	tr.Description = req.Description
+
+	if req.Date != "" {
+		date, err := time.Parse("2006-01-02", req.Date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tr.Date = date
+	}
+
+	return tr, nil
+}
+
+func isRequestValid(m *TransferRequest) (bool, error) {
+	validate := validator.New()
+	err := validate.Struct(m)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	case helpers.ErrConflict, helpers.ErrReconciled:
+		return http.StatusConflict
+	case helpers.ErrBadParamInput:
+		return http.StatusBadRequest
+	case helpers.ErrPeriodClosed:
+		return http.StatusLocked
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,14 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Repository interface {
+	FetchById(ctx context.Context, userId int, id int) (res *models.Transfer, err error)
+	Store(ctx context.Context, t *models.Transfer) error
+	Update(ctx context.Context, t *models.Transfer) error
+	Delete(ctx context.Context, userId int, id int) error
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/audit"
+	adr "github.com/arham09/fin-api/modules/audit/repository"
+	pdr "github.com/arham09/fin-api/modules/period/repository"
+	"github.com/arham09/fin-api/modules/transfer"
+	"github.com/sirupsen/logrus"
+)
+
+// trxName is the name given to both transactions of a transfer.
+const trxName = "Transfer"
+
+// selectTransfer lists the columns scanned by scanTransfer.
+const selectTransfer = `SELECT tr.id, tr.user_id, tr.from_account_id, fa.name, tr.to_account_id, ta.name, tr.amount, tr.description, tr.out_trx_id, tr.in_trx_id, tr.trx_date, tr.status, tr.created_at, tr.updated_at
+	FROM transfers tr LEFT JOIN accounts fa ON tr.from_account_id=fa.id LEFT JOIN accounts ta ON tr.to_account_id=ta.id`
+
+type mySqlTransferRepository struct {
+	Conn *sql.DB
+}
+
+func NewMysqlTransferRepository(Conn *sql.DB) transfer.Repository {
+	return &mySqlTransferRepository{Conn}
+}
+
+func (m *mySqlTransferRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mySqlTransferRepository) adjustBalance(ctx context.Context, tx *sql.Tx, userId int, accountId int, amount models.Money) error {
+	query := `UPDATE accounts SET balance = balance + CAST(? AS DECIMAL(19,4)) WHERE user_id = ? AND id = ?`
+
+	_, err := tx.ExecContext(ctx, query, amount, userId, accountId)
+
+	return err
+}
+
+// applyBalances moves amount from the source to the destination account; a negative amount undoes a transfer.
+func (m *mySqlTransferRepository) applyBalances(ctx context.Context, tx *sql.Tx, t *models.Transfer, amount models.Money) error {
+	err := m.adjustBalance(ctx, tx, t.UserID, t.FromAccount.ID, -amount)
+
+	if err != nil {
+		return err
+	}
+
+	return m.adjustBalance(ctx, tx, t.UserID, t.ToAccount.ID, amount)
+}
+
+// scanTransfer reads the row selected with selectTransfer.
+func scanTransfer(row *sql.Row) (*models.Transfer, error) {
+	t := new(models.Transfer)
+	status := int(0)
+
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.FromAccount.ID,
+		&t.FromAccount.Name,
+		&t.ToAccount.ID,
+		&t.ToAccount.Name,
+		&t.Amount,
+		&t.Description,
+		&t.OutTrxID,
+		&t.InTrxID,
+		&t.Date,
+		&status,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status == 1 {
+		t.Status = "active"
+	} else {
+		t.Status = "inactive"
+	}
+
+	return t, nil
+}
+
+func (m *mySqlTransferRepository) FetchById(ctx context.Context, userId int, id int) (res *models.Transfer, err error) {
+	query := selectTransfer + ` WHERE tr.status=1 AND tr.user_id = ? AND tr.id = ?`
+
+	res, err = scanTransfer(m.Conn.QueryRowContext(ctx, query, userId, id))
+
+	if err != nil && err != helpers.ErrNotFound {
+		logrus.Error(err)
+	}
+
+	return res, err
+}
+
+// lock reads an active transfer, locking the row until the surrounding database transaction ends.
+func (m *mySqlTransferRepository) lock(ctx context.Context, tx *sql.Tx, userId int, id int) (*models.Transfer, error) {
+	query := selectTransfer + ` WHERE tr.status=1 AND tr.user_id = ? AND tr.id = ? FOR UPDATE`
+
+	return scanTransfer(tx.QueryRowContext(ctx, query, userId, id))
+}
+
+// logLegs records the change to both transactions of a transfer, with the transfer before and after it.
+func (m *mySqlTransferRepository) logLegs(ctx context.Context, tx *sql.Tx, t *models.Transfer, action string, before interface{}, after interface{}) error {
+	for _, id := range []int{t.OutTrxID, t.InTrxID} {
+		if err := adr.Write(ctx, tx, t.UserID, t.UserID, audit.EntityTransaction, id, action, before, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *mySqlTransferRepository) storeTrx(ctx context.Context, tx *sql.Tx, t *models.Transfer, accountId int, trxType string, amountIn models.Money, amountOut models.Money) (int, error) {
+	query := `INSERT transactions SET user_id=?, transfer_id=?, name=?, account_id=?, type=?, description=?, amount_in=?, amount_out=?, trx_date=?, created_at=?, updated_at=?`
+
+	res, err := tx.ExecContext(ctx, query, t.UserID, t.ID, trxName, accountId, trxType, t.Description, amountIn, amountOut, t.Date.Format("2006-01-02"), t.CreatedAt, t.UpdatedAt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	lastID, err := res.LastInsertId()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(lastID), nil
+}
+
+func (m *mySqlTransferRepository) Store(ctx context.Context, t *models.Transfer) error {
+	query := `INSERT transfers SET user_id=?, from_account_id=?, to_account_id=?, amount=?, description=?, trx_date=?, created_at=?, updated_at=?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		if err := pdr.CheckOpen(ctx, tx, t.UserID, t.Date); err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, t.UserID, t.FromAccount.ID, t.ToAccount.ID, t.Amount, t.Description, t.Date.Format("2006-01-02"), t.CreatedAt, t.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		t.ID = int(lastID)
+
+		t.OutTrxID, err = m.storeTrx(ctx, tx, t, t.FromAccount.ID, "out", 0, t.Amount)
+
+		if err != nil {
+			return err
+		}
+
+		t.InTrxID, err = m.storeTrx(ctx, tx, t, t.ToAccount.ID, "in", t.Amount, 0)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transfers SET out_trx_id=?, in_trx_id=? WHERE id = ?`, t.OutTrxID, t.InTrxID, t.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.applyBalances(ctx, tx, t, t.Amount); err != nil {
+			return err
+		}
+
+		return m.logLegs(ctx, tx, t, "create", nil, t)
+	})
+}
+
+func (m *mySqlTransferRepository) Update(ctx context.Context, t *models.Transfer) error {
+	query := `UPDATE transfers SET from_account_id=?, to_account_id=?, amount=?, description=?, trx_date=?, updated_at=? WHERE status=1 AND user_id = ? AND id = ?`
+	trxQuery := `UPDATE transactions SET account_id=?, description=?, amount_in=?, amount_out=?, trx_date=?, updated_at=? WHERE status=1 AND user_id = ? AND transfer_id = ? AND type = ?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		old, err := m.lock(ctx, tx, t.UserID, t.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err = pdr.CheckOpen(ctx, tx, t.UserID, old.Date, t.Date); err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, t.FromAccount.ID, t.ToAccount.ID, t.Amount, t.Description, t.Date.Format("2006-01-02"), t.UpdatedAt, t.UserID, t.ID)
+		if err != nil {
+			return err
+		}
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affect != 1 {
+			err = fmt.Errorf("Weird  Behaviour. Total Affected: %d", affect)
+
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, trxQuery, t.FromAccount.ID, t.Description, 0, t.Amount, t.Date.Format("2006-01-02"), t.UpdatedAt, t.UserID, t.ID, "out")
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, trxQuery, t.ToAccount.ID, t.Description, t.Amount, 0, t.Date.Format("2006-01-02"), t.UpdatedAt, t.UserID, t.ID, "in")
+
+		if err != nil {
+			return err
+		}
+
+		err = m.applyBalances(ctx, tx, old, -old.Amount)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.applyBalances(ctx, tx, t, t.Amount); err != nil {
+			return err
+		}
+
+		after, err := m.lock(ctx, tx, t.UserID, t.ID)
+
+		if err != nil {
+			return err
+		}
+
+		return m.logLegs(ctx, tx, old, "update", old, after)
+	})
+}
+
+func (m *mySqlTransferRepository) Delete(ctx context.Context, userId int, id int) error {
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		old, err := m.lock(ctx, tx, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = pdr.CheckOpen(ctx, tx, userId, old.Date); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transfers SET status=0 WHERE user_id = ? AND id = ?`, userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET status=0, deleted_at=? WHERE status=1 AND user_id = ? AND transfer_id = ?`, time.Now(), userId, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.applyBalances(ctx, tx, old, -old.Amount); err != nil {
+			return err
+		}
+
+		return m.logLegs(ctx, tx, old, "delete", old, nil)
+	})
+}
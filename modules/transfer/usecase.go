@@ -0,0 +1,14 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchById(c context.Context, userId int, id int) (*models.Transfer, error)
+	Create(c context.Context, t *models.Transfer) error
+	Update(c context.Context, t *models.Transfer) (*models.Transfer, error)
+	Delete(c context.Context, userId int, id int) error
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/loan"
+	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/arham09/fin-api/modules/transfer"
+)
+
+type transferUsecase struct {
+	transferRepo   transfer.Repository
+	accountRepo    account.Repository
+	trxRepo        transaction.Repository
+	loanRepo       loan.Repository
+	contextTimeout time.Duration
+}
+
+func NewTransferUsecase(t transfer.Repository, a account.Repository, tr transaction.Repository, l loan.Repository, timeout time.Duration) transfer.Usecase {
+	return &transferUsecase{
+		transferRepo:   t,
+		accountRepo:    a,
+		trxRepo:        tr,
+		loanRepo:       l,
+		contextTimeout: timeout,
+	}
+}
+
+// validate checks that both sides of the transfer are distinct accounts owned by the user and held in the same currency.
+// Loans are paid with transactions of their own account, which split the payment, so a transfer cannot go into one.
+func (t *transferUsecase) validate(ctx context.Context, tr *models.Transfer) error {
+	if tr.FromAccount.ID == tr.ToAccount.ID || tr.Amount <= 0 {
+		return helpers.ErrBadParamInput
+	}
+
+	from, err := t.accountRepo.FetchById(ctx, tr.UserID, tr.FromAccount.ID)
+
+	if err != nil {
+		return err
+	}
+
+	to, err := t.accountRepo.FetchById(ctx, tr.UserID, tr.ToAccount.ID)
+
+	if err != nil {
+		return err
+	}
+
+	// Both legs carry the same amount, which is only meaningful within a single currency.
+	if from.Currency != to.Currency {
+		return helpers.ErrBadParamInput
+	}
+
+	if to.Type == loan.AccountType {
+		_, err = t.loanRepo.FetchByAccount(ctx, tr.UserID, to.ID)
+
+		if err == nil {
+			return helpers.ErrBadParamInput
+		}
+
+		if err != helpers.ErrNotFound {
+			return err
+		}
+	}
+
+	tr.FromAccount = *from
+	tr.ToAccount = *to
+
+	return nil
+}
+
+// checkLegs refuses changes to a transfer once either of its transactions has been reconciled.
+func (t *transferUsecase) checkLegs(ctx context.Context, tr *models.Transfer) error {
+	for _, trxId := range []int{tr.OutTrxID, tr.InTrxID} {
+		trx, err := t.trxRepo.FetchById(ctx, tr.UserID, trxId)
+
+		if err != nil {
+			return err
+		}
+
+		if trx.Cleared == "reconciled" {
+			return helpers.ErrReconciled
+		}
+	}
+
+	return nil
+}
+
+func (t *transferUsecase) FetchById(c context.Context, userId int, id int) (*models.Transfer, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	res, err := t.transferRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (t *transferUsecase) Create(c context.Context, tr *models.Transfer) error {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	err := t.validate(ctx, tr)
+
+	if err != nil {
+		return err
+	}
+
+	if tr.Date.IsZero() {
+		tr.Date = time.Now()
+	}
+
+	tr.CreatedAt = time.Now()
+	tr.UpdatedAt = time.Now()
+
+	return t.transferRepo.Store(ctx, tr)
+}
+
+func (t *transferUsecase) Update(c context.Context, tr *models.Transfer) (*models.Transfer, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	existing, err := t.transferRepo.FetchById(ctx, tr.UserID, tr.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.checkLegs(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	if tr.Date.IsZero() {
+		tr.Date = existing.Date
+	}
+
+	err = t.validate(ctx, tr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tr.UpdatedAt = time.Now()
+
+	err = t.transferRepo.Update(ctx, tr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.transferRepo.FetchById(ctx, tr.UserID, tr.ID)
+}
+
+func (t *transferUsecase) Delete(c context.Context, userId int, id int) error {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+
+	defer cancel()
+
+	existing, err := t.transferRepo.FetchById(ctx, userId, id)
+
+	if err != nil {
+		return err
+	}
+
+	if err = t.checkLegs(ctx, existing); err != nil {
+		return err
+	}
+
+	return t.transferRepo.Delete(ctx, userId, id)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arham09/fin-api/helpers"
+	"github.com/arham09/fin-api/middleware"
+	"github.com/arham09/fin-api/modules/trash"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type TrashHandler struct {
+	TrashUsecase trash.Usecase
+}
+
+func NewTrashHandler(e *echo.Echo, tu trash.Usecase, middleware *middleware.Middleware) {
+	handler := &TrashHandler{
+		TrashUsecase: tu,
+	}
+
+	e.GET("/v1/trash", handler.FetchAll, middleware.Authorize)
+}
+
+// ShowTrash godoc
+// @Summary Show the Trash
+// @Description get the deleted accounts and transactions that can still be restored, most recently deleted first. Transactions deleted together with their account are restored with it and are not listed
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Insert your access token" default(Bearer <Add access token here>)
+// @Success 200 {object} models.Trash
+// @Header 200 {string} Token "qwerty"
+// @Router /trash [get]
+func (h *TrashHandler) FetchAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := c.Get("userId").(int)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res, err := h.TrashUsecase.FetchAll(ctx, userId)
+
+	if err != nil {
+		return c.JSON(getStatusCode(err), map[string]string{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	logrus.Error(err)
+	switch err {
+	case helpers.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case helpers.ErrNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/modules/trash"
+	"github.com/sirupsen/logrus"
+)
+
+// TrashScheduler purges what has been in the trash for longer than Retention, checking on every Interval.
+type TrashScheduler struct {
+	TrashUsecase trash.Usecase
+	Retention    time.Duration
+	Interval     time.Duration
+}
+
+func NewTrashScheduler(tu trash.Usecase, retention time.Duration, interval time.Duration) *TrashScheduler {
+	return &TrashScheduler{
+		TrashUsecase: tu,
+		Retention:    retention,
+		Interval:     interval,
+	}
+}
+
+// Start runs once right away and then on every tick until ctx is done.
+func (s *TrashScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TrashScheduler) run(ctx context.Context) {
+	purged, err := s.TrashUsecase.Purge(ctx, time.Now().Add(-s.Retention))
+
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	if purged > 0 {
+		logrus.Infof("trash scheduler purged %d records", purged)
+	}
+}
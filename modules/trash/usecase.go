@@ -0,0 +1,15 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+)
+
+type Usecase interface {
+	FetchAll(c context.Context, userId int) (*models.Trash, error)
+	// Purge hard deletes everything that went to the trash before the given time, attachments included, and returns
+	// how many accounts and transactions were removed.
+	Purge(c context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/arham09/fin-api/models"
+	"github.com/arham09/fin-api/modules/account"
+	"github.com/arham09/fin-api/modules/attachment"
+	"github.com/arham09/fin-api/modules/transaction"
+	"github.com/arham09/fin-api/modules/trash"
+	"github.com/sirupsen/logrus"
+)
+
+type trashUsecase struct {
+	accountRepo    account.Repository
+	trxRepo        transaction.Repository
+	attachmentUc   attachment.Usecase
+	contextTimeout time.Duration
+}
+
+func NewTrashUsecase(a account.Repository, t transaction.Repository, at attachment.Usecase, timeout time.Duration) trash.Usecase {
+	return &trashUsecase{
+		accountRepo:    a,
+		trxRepo:        t,
+		attachmentUc:   at,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *trashUsecase) FetchAll(c context.Context, userId int) (*models.Trash, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	accounts, err := u.accountRepo.FetchDeleted(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := u.trxRepo.FetchDeleted(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Trash{
+		Accounts:     accounts,
+		Transactions: transactions,
+	}, nil
+}
+
+func (u *trashUsecase) Purge(c context.Context, before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+
+	list, err := u.trxRepo.FetchPurgeable(ctx, before)
+
+	cancel()
+
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int, 0, len(list))
+	owners := make(map[int]int, len(list))
+
+	for _, t := range list {
+		ids = append(ids, t.ID)
+		owners[t.ID] = t.UserID
+	}
+
+	ctx, cancel = context.WithTimeout(c, u.contextTimeout)
+
+	defer cancel()
+
+	purged, err := u.trxRepo.Purge(ctx, ids)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Attachments follow only the rows the purge actually removed; a failure leaves orphaned files, not rows.
+	for _, id := range purged {
+		if err := u.attachmentUc.DeleteAll(c, owners[id], id); err != nil {
+			logrus.Errorf("transaction %d: removing attachments: %v", id, err)
+		}
+	}
+
+	accounts, err := u.accountRepo.Purge(ctx, before)
+
+	if err != nil {
+		return len(purged), err
+	}
+
+	return len(purged) + accounts, nil
+}
@@ -9,5 +9,6 @@ import (
 type Repository interface {
 	FetchById(ctx context.Context, id int) (res *models.User, err error)
 	FetchByEmail(ctx context.Context, email string) (res *models.User, err error)
+	// Store inserts the user together with the default categories in one database transaction.
 	Store(ctx context.Context, u *models.User) error
 }
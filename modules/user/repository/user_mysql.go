@@ -7,6 +7,7 @@ import (
 
 	"github.com/arham09/fin-api/helpers"
 	"github.com/arham09/fin-api/models"
+	cr "github.com/arham09/fin-api/modules/category/repository"
 	"github.com/arham09/fin-api/modules/user"
 	"github.com/sirupsen/logrus"
 )
@@ -45,6 +46,7 @@ func (m *mySqlUserRepository) fetch(ctx context.Context, query string, args ...i
 			&t.Email,
 			&t.Password,
 			&t.Name,
+			&t.Role,
 			&status,
 			&t.CreatedAt,
 			&t.UpdatedAt,
@@ -68,7 +70,7 @@ func (m *mySqlUserRepository) fetch(ctx context.Context, query string, args ...i
 }
 
 func (m *mySqlUserRepository) FetchById(ctx context.Context, id int) (res *models.User, err error) {
-	query := `SELECT id, email, password, name, status, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT id, email, password, name, role, status, created_at, updated_at FROM users WHERE id = ?`
 
 	list, err := m.fetch(ctx, query, id)
 
@@ -86,7 +88,7 @@ func (m *mySqlUserRepository) FetchById(ctx context.Context, id int) (res *model
 }
 
 func (m *mySqlUserRepository) FetchByEmail(ctx context.Context, email string) (res *models.User, err error) {
-	query := `SELECT id, email, password, name, status, created_at, updated_at FROM users WHERE email = ?`
+	query := `SELECT id, email, password, name, role, status, created_at, updated_at FROM users WHERE email = ?`
 
 	list, err := m.fetch(ctx, query, email)
 
@@ -105,28 +107,43 @@ func (m *mySqlUserRepository) FetchByEmail(ctx context.Context, email string) (r
 	return res, nil
 }
 
-func (m *mySqlUserRepository) Store(ctx context.Context, u *models.User) error {
-	query := `INSERT users SET email=?, password=?, name=?, created_at=?, updated_at=?`
-
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+func (m *mySqlUserRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.Conn.BeginTx(ctx, nil)
 
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, u.Email, u.Password, u.Name, u.CreatedAt, u.UpdatedAt)
+	err = fn(tx)
 
 	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logrus.Error(rbErr)
+		}
 		return err
 	}
 
-	lastID, err := res.LastInsertId()
+	return tx.Commit()
+}
 
-	if err != nil {
-		return err
-	}
+func (m *mySqlUserRepository) Store(ctx context.Context, u *models.User) error {
+	query := `INSERT users SET email=?, password=?, name=?, created_at=?, updated_at=?`
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, u.Email, u.Password, u.Name, u.CreatedAt, u.UpdatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		lastID, err := res.LastInsertId()
+
+		if err != nil {
+			return err
+		}
 
-	u.ID = int(lastID)
+		u.ID = int(lastID)
 
-	return nil
+		return cr.StoreDefaults(ctx, tx, u.ID)
+	})
 }
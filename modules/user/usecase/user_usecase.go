@@ -65,13 +65,7 @@ func (u *userUsecase) Register(c context.Context, user *models.User) error {
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
-	err := u.userRepo.Store(ctx, user)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return u.userRepo.Store(ctx, user)
 }
 
 func (u *userUsecase) Login(c context.Context, user *models.User) (string, error) {